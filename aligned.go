@@ -0,0 +1,207 @@
+package base32
+
+import "unsafe"
+
+// headSymbols holds the number of symbols of the leading group of a
+// right-aligned encoding for each remainder of encoded bytes modulo 5.
+var headSymbols = [5]int{0, 2, 4, 5, 7}
+
+// headMask holds, for each valid number of symbols in the leading group
+// of a right-aligned encoding, the bits of the leading symbol that do
+// not hold data.
+var headMask = [8]byte{2: 0x18, 4: 0x1E, 5: 0x10, 7: 0x1C}
+
+// RightAligned creates a new encoding identical to enc except that the
+// encoded value is aligned to the right: the unused bits are the high
+// bits of the leading symbol rather than the low bits of the final one.
+//
+// This is how Crockford describes encoding a number and how ULIDs are
+// encoded, so the symbols read as the big-endian number held by the
+// bytes. A 16 byte value encodes to 26 symbols whose first symbol is at
+// most '7'.
+//
+// Lengths are unchanged. The decoder rejects a leading symbol with any
+// of its unused bits set with an Overflow *DecodeError unless enc was
+// created with WithLenientTail, in which case those bits are ignored.
+//
+// EncodeBits and DecodeBits always align to the left and the tail bit
+// functions panic for a right-aligned encoding.
+//
+// This function panics if enc is padded. NewEncoder and NewDecoder
+// panic for a right-aligned encoding as the leading group depends on
+// the total length.
+func (enc *Encoding) RightAligned() *Encoding {
+	if enc.padChar != NoPadding {
+		panic("base32: right-aligned encoding can not be padded")
+	}
+
+	result := *enc
+	result.rightAligned = true
+
+	return &result
+}
+
+// encodeRightAligned encodes the n bytes at srcPtr into dstPtr with the
+// leading partial group first.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) encodeRightAligned(dstPtr, srcPtr unsafe.Pointer, n int) {
+	r := n % 5
+	h := headSymbols[r]
+
+	if r > 0 {
+		// The leading bytes are placed at the end of the symbols of the
+		// head within a 40 bit group which the kernel encodes.
+		var v uint64
+		for i := range r {
+			v = v<<8 | uint64(*(*byte)(unsafe.Add(srcPtr, i)))
+		}
+
+		v <<= 40 - 5*h
+
+		in := [5]byte{byte(v >> 32), byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+		var out [8]byte
+
+		enc.encode(unsafe.Pointer(&out[0]), unsafe.Pointer(&in[0]), len(in))
+
+		copy(unsafe.Slice((*byte)(dstPtr), h), out[:])
+	}
+
+	if n > r {
+		enc.encode(unsafe.Add(dstPtr, h), unsafe.Add(srcPtr, r), n-r)
+	}
+}
+
+// decodeRightAligned decodes the first n bytes at srcPtr, which hold the
+// symbols of a right-aligned encoded value along with any separators.
+//
+// If dstPtr is nil the input is only validated, which is only supported
+// for constant-time encodings.
+//
+// invariants:
+//
+// - the n bytes hold a valid number of symbols, as checked by decodeLen
+func (enc *Encoding) decodeRightAligned(dstPtr, srcPtr unsafe.Pointer, n int) error {
+	symbols := n
+	if enc.sepTab != nil {
+		symbols = enc.countSymbols(srcPtr, n)
+	}
+
+	h := symbols % 8
+
+	// Gather the symbols of the head, completed with zero valued
+	// symbols, so the kernel decodes them as a whole group.
+	var in [8]byte
+	var inOff [8]int // input offsets of the symbols in in
+	var i int
+
+	for k := 0; k < h; i++ {
+		c := *(*byte)(unsafe.Add(srcPtr, i))
+
+		if enc.sepTab != nil && enc.sepTab[c] {
+			continue
+		}
+
+		in[k] = c
+		inOff[k] = i
+		k++
+	}
+
+	// A constant-time encoding records an error found in the head and
+	// reports it only once the remaining symbols have been processed.
+	var headErr error
+
+	if h > 0 {
+		for k := h; k < len(in); k++ {
+			in[k] = enc.encodeTab[0]
+		}
+
+		var out [5]byte
+
+		if err := enc.decode(unsafe.Pointer(&out[0]), unsafe.Pointer(&in[0]), len(in)); err != nil {
+			headErr = groupError(err, &inOff)
+		} else if (out[0]>>3)&headMask[h]&enc.tailCheck() != 0 {
+			headErr = &DecodeError{Offset: int64(inOff[0]), Char: in[0], Kind: Overflow}
+		}
+
+		if headErr != nil && !enc.constantTime {
+			return headErr
+		}
+
+		v := uint64(out[0])<<32 | uint64(out[1])<<24 | uint64(out[2])<<16 | uint64(out[3])<<8 | uint64(out[4])
+		v >>= 40 - 5*h
+
+		if dstPtr != nil {
+			r := decodedLen(h)
+			for j := r - 1; j >= 0; j-- {
+				*(*byte)(unsafe.Add(dstPtr, j)) = byte(v)
+				v >>= 8
+			}
+
+			dstPtr = unsafe.Add(dstPtr, r)
+		}
+	}
+
+	if i == n {
+		return headErr
+	}
+
+	// The remaining symbols form whole groups which decode exactly as
+	// they would for a left-aligned encoding.
+	rest := unsafe.Add(srcPtr, i)
+
+	var err error
+	switch {
+	case dstPtr == nil:
+		err = enc.decodeConstantTime(nil, rest, n-i)
+	case enc.sepTab != nil:
+		err = enc.decodeSeparated(dstPtr, rest, n-i)
+	default:
+		err = enc.decode(dstPtr, rest, n-i)
+	}
+
+	if headErr != nil {
+		return headErr
+	}
+
+	if err != nil {
+		err.(*DecodeError).Offset += int64(i)
+		return err
+	}
+
+	return nil
+}
+
+// headLen returns the number of symbols in the leading group of the
+// encoded form of n bytes if enc is right-aligned, or 0 otherwise.
+// Decoding checks that group, and reports any error in it, before the
+// symbols that follow.
+func (enc *Encoding) headLen(n int) int {
+	if !enc.rightAligned {
+		return 0
+	}
+
+	return headSymbols[n%5]
+}
+
+// tailError returns a *DecodeError if the first or last symbol of k
+// symbols holds non-zero unused bits, depending on the alignment of enc.
+// The symbols are v0 and vn, found at offsets off0 and offn of the input
+// at srcPtr.
+func (enc *Encoding) tailError(srcPtr unsafe.Pointer, k int, v0, vn byte, off0, offn int) error {
+	if enc.rightAligned {
+		if v0&headMask[k%8]&enc.tailCheck() != 0 {
+			return &DecodeError{Offset: int64(off0), Char: *(*byte)(unsafe.Add(srcPtr, off0)), Kind: Overflow}
+		}
+
+		return nil
+	}
+
+	if vn&tailMask[k%8]&enc.tailCheck() != 0 {
+		return &DecodeError{Offset: int64(offn), Char: *(*byte)(unsafe.Add(srcPtr, offn)), Kind: NonCanonicalTail}
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package base32
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRightAligned(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	r := rand.New(rand.NewPCG(3, 4))
+
+	for _, enc := range []*Encoding{
+		Crockford.RightAligned(),
+		Crockford.Strict().RightAligned(),
+		RawStdEncoding.RightAligned(),
+		Crockford.RightAligned().ConstantTime(),
+	} {
+		for n := range 22 {
+			src := make([]byte, n)
+			for i := range src {
+				src[i] = byte(r.Uint32())
+			}
+
+			s := enc.EncodeString(string(src))
+			is.Len(s, encodedLenExpression(n))
+			is.Equal(s, string(enc.Encode(src)))
+			is.Equal("test_"+s, string(enc.AppendEncode([]byte("test_"), src)))
+
+			if n == 0 {
+				continue
+			}
+
+			// the symbols are the digits of the big-endian number held
+			// by src
+			digits := new(big.Int).SetBytes(src).Text(32)
+			exp := make([]byte, len(s))
+			for i := range exp {
+				exp[i] = enc.encodeTab[0]
+			}
+			for i := range len(digits) {
+				v, _ := new(big.Int).SetString(digits[i:i+1], 32)
+				exp[len(exp)-len(digits)+i] = enc.encodeTab[v.Uint64()]
+			}
+			is.Equal(string(exp), s)
+
+			b, err := enc.DecodeString(s)
+			is.Nil(err)
+			is.Equal(src, b)
+
+			b, err = enc.DecodeSecret([]byte(s))
+			is.Nil(err)
+			is.Equal(src, b)
+
+			is.Nil(enc.ValidStringLen(s, n))
+			is.True(enc.IsCanonicalString(s))
+
+			in := strings.ToLower(s)
+			if enc.lenientTab != nil {
+				in = s
+			}
+
+			norm, err := enc.NormalizeString(in)
+			is.Nil(err)
+			is.Equal(s, norm)
+
+			dst := make([]byte, n)
+			is.Nil(enc.UnsafeDecode(dst, []byte(s)))
+			is.Equal(src, dst)
+
+			// setting an unused bit of the leading symbol overflows
+			h := len(s) % 8
+			if h == 0 {
+				continue
+			}
+
+			v := enc.decodeTab[s[0]] | headMask[h]&-headMask[h]
+			bad := string(enc.encodeTab[v]) + s[1:]
+
+			exp2 := &DecodeError{Offset: 0, Char: bad[0], Kind: Overflow}
+
+			_, err = enc.DecodeString(bad)
+			is.Equal(exp2, err, bad)
+			is.ErrorIs(err, ErrOverflow)
+			is.Equal(exp2, enc.ValidString(bad), bad)
+			is.False(enc.IsCanonicalString(bad), bad)
+
+			_, err = enc.NormalizeString(bad)
+			is.Equal(exp2, err, bad)
+
+			// unless the encoding is lenient
+			lenient := enc.WithLenientTail()
+
+			b, err = lenient.DecodeString(bad)
+			is.Nil(err, bad)
+			is.Equal(src, b, bad)
+			is.Nil(lenient.ValidString(bad), bad)
+
+			norm, err = lenient.NormalizeString(bad)
+			is.Nil(err, bad)
+			is.Equal(s, norm, bad)
+
+			// invalid symbols are reported where they are, whether in
+			// the head or in the groups that follow it
+			for _, i := range []int{0, h - 1, len(s) - 1} {
+				bad := []byte(s)
+				bad[i] = '!'
+
+				_, err := enc.Decode(bad)
+				is.Equal(&DecodeError{Offset: int64(i), Char: '!', Kind: InvalidChar}, err, string(bad))
+				is.Equal(err, enc.Valid(bad), string(bad))
+			}
+		}
+	}
+
+	// ULIDs are 16 bytes encoded as 26 right-aligned Crockford symbols
+	ulid := Crockford.RightAligned()
+
+	const id = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	b, err := ulid.DecodeString(id)
+	is.Nil(err)
+	is.Len(b, 16)
+	is.Equal([]byte{0x01, 0x56, 0x3e, 0x3a, 0xb5, 0xd3}, b[:6])
+	is.Equal(id, ulid.EncodeString(string(b)))
+
+	is.Equal("7ZZZZZZZZZZZZZZZZZZZZZZZZZ", ulid.EncodeString(string(bytes.Repeat([]byte{0xFF}, 16))))
+
+	_, err = ulid.DecodeString("8ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	is.Equal(&DecodeError{Offset: 0, Char: '8', Kind: Overflow}, err)
+	is.Equal(`base32 leading symbol overflow in "8" at offset 0`, err.Error())
+
+	// the symbols read as a number
+	is.Equal("000FZ", ulid.EncodeString("\x00\x01\xFF"))
+	is.Equal("000ZY", Crockford.EncodeString("\x00\x01\xFF"))
+
+	// separators
+	hyphens := ulid.WithSeparators("-")
+
+	b, err = hyphens.DecodeString("-01AR-Z3ND-EKTS-V4RR-FFQ6-9G5F-AV-")
+	is.Nil(err)
+	is.Equal(id, ulid.EncodeString(string(b)))
+	is.Nil(hyphens.ValidString("-01AR-Z3ND-EKTS-V4RR-FFQ6-9G5F-AV-"))
+
+	norm, err := hyphens.NormalizeString("-01ar-z3nd-ekts-v4rr-ffq6-9g5f-av-")
+	is.Nil(err)
+	is.Equal(id, norm)
+
+	for _, tc := range []struct {
+		src    string
+		kind   DecodeErrorKind
+		char   byte
+		offset int64
+	}{
+		{"-8Z", Overflow, '8', 1},
+		{"-0-U", InvalidChar, 'U', 3},
+		{"-01AR-Z3ND-EKTS-V4RR-FFQ6-9G5F-AU-", InvalidChar, 'U', 32},
+		{"--U1AR-Z3ND-EKTS-V4RR-FFQ6-9G5F-AV", InvalidChar, 'U', 2},
+	} {
+		_, err := hyphens.DecodeString(tc.src)
+		is.Equal(&DecodeError{Offset: tc.offset, Char: tc.char, Kind: tc.kind}, err, tc.src)
+		is.Equal(err, hyphens.ValidString(tc.src), tc.src)
+	}
+
+	// input holding nothing but separators decodes to nothing
+	b, err = hyphens.DecodeString("--")
+	is.Nil(err)
+	is.Nil(b)
+
+	// an overflowing head is reported before a later invalid byte by
+	// every entry point
+	for _, enc := range []*Encoding{ulid, ulid.ConstantTime(), ulid.Strict()} {
+		const src = "800000000000000000000000U0"
+		exp := &DecodeError{Offset: 0, Char: '8', Kind: Overflow}
+
+		_, err := enc.DecodeString(src)
+		is.Equal(exp, err)
+		is.Equal(exp, enc.ValidString(src))
+
+		_, err = enc.NormalizeString(src)
+		is.Equal(exp, err)
+	}
+
+	// combinations that are not supported
+	is.PanicsWithValue("base32: right-aligned encoding can not be padded", func() {
+		StdEncoding.RightAligned()
+	})
+	is.PanicsWithValue("base32: right-aligned encoding can not be padded", func() {
+		ulid.WithPadding(StdPadding)
+	})
+	is.NotPanics(func() {
+		ulid.WithPadding(NoPadding)
+	})
+	is.PanicsWithValue("base32: right-aligned encoding can not be streamed", func() {
+		ulid.NewEncoder(&bytes.Buffer{})
+	})
+	is.PanicsWithValue("base32: right-aligned encoding can not be streamed", func() {
+		ulid.NewDecoder(&bytes.Buffer{})
+	})
+	is.PanicsWithValue("base32: right-aligned encoding has no tail bits", func() {
+		ulid.EncodeWithTailBits([]byte{1}, 0)
+	})
+	is.PanicsWithValue("base32: right-aligned encoding has no tail bits", func() {
+		_, _, _, _ = ulid.DecodeStringTailBits("01")
+	})
+}
+
+func TestRightAlignedErrorOrder(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	r := rand.New(rand.NewPCG(19, 20))
+
+	for _, enc := range []*Encoding{
+		Crockford.RightAligned(),
+		Crockford.Strict().RightAligned(),
+		Crockford.RightAligned().ConstantTime(),
+		Crockford.RightAligned().WithSeparators("-"),
+		Crockford.RightAligned().WithLenientTail(),
+	} {
+		for range 2000 {
+			src := make([]byte, 1+r.IntN(21))
+			for i := range src {
+				src[i] = byte(r.Uint32())
+			}
+
+			// mangle a few bytes with invalid bytes, aliases, lowercase
+			// letters, separators, and symbols that overflow the head
+			in := []byte(enc.EncodeString(string(src)))
+			for range r.IntN(4) {
+				in[r.IntN(len(in))] = "U*-oOlL8Zz"[r.IntN(10)]
+			}
+
+			_, exp := enc.Decode(in)
+			is.Equal(exp, enc.Valid(in), "%q", in)
+
+			_, err := enc.Normalize(in)
+			is.Equal(exp, err, "%q", in)
+		}
+	}
+}
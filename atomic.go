@@ -0,0 +1,130 @@
+package base32
+
+import (
+	"slices"
+	"unsafe"
+)
+
+// DecodeSecret returns the Crockford decoded form of src or, on failure,
+// nil and an error without leaking any partially decoded data.
+//
+// See Encoding.DecodeSecret for details.
+func DecodeSecret(src []byte) ([]byte, error) {
+	return Crockford.DecodeSecret(src)
+}
+
+// DecodeSecretString returns the Crockford decoded form of src or, on
+// failure, nil and an error without leaking any partially decoded data.
+//
+// See Encoding.DecodeSecret for details.
+func DecodeSecretString(src string) ([]byte, error) {
+	return Crockford.DecodeSecretString(src)
+}
+
+// AppendDecodeAtomic returns the Crockford decoded form of src appended
+// to dst or, on failure, dst with its original length.
+//
+// See Encoding.AppendDecodeAtomic for details.
+func AppendDecodeAtomic(dst, src []byte) ([]byte, error) {
+	return Crockford.AppendDecodeAtomic(dst, src)
+}
+
+// AppendDecodeStringAtomic returns the Crockford decoded form of src
+// appended to dst or, on failure, dst with its original length.
+//
+// See Encoding.AppendDecodeAtomic for details.
+func AppendDecodeStringAtomic(dst []byte, src string) ([]byte, error) {
+	return Crockford.AppendDecodeStringAtomic(dst, src)
+}
+
+// DecodeSecret returns the decoded form of src if src is not empty. If
+// src is empty nil is returned.
+//
+// Unlike Decode, if an error is returned the returned slice is always
+// nil and every byte written while decoding has been set to zero before
+// returning, so no partially decoded data escapes.
+func (enc *Encoding) DecodeSecret(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	dst, err := enc.appendDecodeAtomic(nil, unsafe.Pointer(&src[0]), len(src))
+	if err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// DecodeSecretString returns the decoded form of src if src is not
+// empty. If src is empty nil is returned.
+//
+// See DecodeSecret for details.
+func (enc *Encoding) DecodeSecretString(src string) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	dst, err := enc.appendDecodeAtomic(nil, unsafe.Pointer(unsafe.StringData(src)), len(src))
+	if err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// AppendDecodeAtomic returns the decoded form of src appended to dst if
+// src is not empty. If src is empty dst is returned as-is.
+//
+// Unlike AppendDecode, if an error is returned the returned slice is
+// dst with its original length and contents. Every byte written while
+// decoding, whether within the capacity of dst or in a newly allocated
+// array, has been set to zero before returning.
+func (enc *Encoding) AppendDecodeAtomic(dst, src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return dst, nil
+	}
+
+	return enc.appendDecodeAtomic(dst, unsafe.Pointer(&src[0]), len(src))
+}
+
+// AppendDecodeStringAtomic returns the decoded form of src appended to
+// dst if src is not empty. If src is empty dst is returned as-is.
+//
+// See AppendDecodeAtomic for details.
+func (enc *Encoding) AppendDecodeStringAtomic(dst []byte, src string) ([]byte, error) {
+	if len(src) == 0 {
+		return dst, nil
+	}
+
+	return enc.appendDecodeAtomic(dst, unsafe.Pointer(unsafe.StringData(src)), len(src))
+}
+
+// appendDecodeAtomic appends the decoded form of the n bytes of encoded
+// input at srcPtr to dst, wiping everything it wrote on failure.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) appendDecodeAtomic(dst []byte, srcPtr unsafe.Pointer, n int) ([]byte, error) {
+	m, size, err := enc.decodeLen(srcPtr, n)
+	if err != nil {
+		return dst, err
+	}
+
+	if size == 0 {
+		return dst, nil
+	}
+
+	orig := len(dst)
+
+	out := slices.Grow(dst, size)
+	out = out[:orig+size]
+
+	if err := enc.decodeInput(unsafe.Pointer(&out[orig]), srcPtr, m); err != nil {
+		clear(out[orig:])
+		return dst, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,126 @@
+package base32
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSecret(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const exp = "1234567890123456789"
+	const src = "64S36D1N6RVKGE9G64S36D1N6RVKGE8"
+
+	b, err := DecodeSecret([]byte(src))
+	is.Nil(err)
+	is.Equal(exp, string(b))
+
+	b, err = DecodeSecretString(src)
+	is.Nil(err)
+	is.Equal(exp, string(b))
+
+	b, err = DecodeSecret(nil)
+	is.Nil(err)
+	is.Nil(b)
+
+	b, err = DecodeSecretString("")
+	is.Nil(err)
+	is.Nil(b)
+
+	b, err = Crockford.WithSeparators("-").DecodeSecretString("--")
+	is.Nil(err)
+	is.Nil(b)
+
+	for _, src := range []string{
+		"64S36D1N6RVKGE9G64S36D1N6RVKGE",
+		"64S36D1N6RVKGE9G64S36D1N6RVKGE4",
+		"64S36D1N6RVKGE9G64S36D1N6RVKGEU",
+	} {
+		b, err := DecodeSecret([]byte(src))
+		is.NotNil(err, src)
+		is.Nil(b, src)
+
+		b, err = DecodeSecretString(src)
+		is.NotNil(err, src)
+		is.Nil(b, src)
+	}
+}
+
+func TestAppendDecodeAtomic(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const exp = "1234567890123456789"
+	const src = "64S36D1N6RVKGE9G64S36D1N6RVKGE8"
+
+	b, err := AppendDecodeAtomic([]byte("test_"), []byte(src))
+	is.Nil(err)
+	is.Equal("test_"+exp, string(b))
+
+	b, err = AppendDecodeStringAtomic([]byte("test_"), src)
+	is.Nil(err)
+	is.Equal("test_"+exp, string(b))
+
+	b, err = AppendDecodeAtomic([]byte("test_"), nil)
+	is.Nil(err)
+	is.Equal("test_", string(b))
+
+	b, err = AppendDecodeStringAtomic([]byte("test_"), "")
+	is.Nil(err)
+	is.Equal("test_", string(b))
+
+	b, err = Crockford.WithSeparators("-").AppendDecodeStringAtomic([]byte("test_"), "--")
+	is.Nil(err)
+	is.Equal("test_", string(b))
+
+	for _, tc := range []struct {
+		enc *Encoding
+		src string
+	}{
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGE"},
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGE4"},
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGEU"},
+		{Crockford.WithSeparators("-"), "64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GEU"},
+		{StdEncoding, "GEZDGNBVGY3TQOJQ=="},
+		{StdEncoding, "GEZDGNBVGY3TQOJQGEZDGNB1"},
+	} {
+		// a destination with spare capacity is wiped in place
+		const fill = 0xAA
+		buf := bytes.Repeat([]byte{fill}, 64)
+		dst := append(buf[:0], "test_"...)
+
+		b, err := tc.enc.AppendDecodeAtomic(dst, []byte(tc.src))
+		is.NotNil(err, tc.src)
+		is.Equal("test_", string(b), tc.src)
+
+		// everything past the original length is either wiped or
+		// untouched
+		for _, c := range buf[len(dst):] {
+			if c != 0 && c != fill {
+				is.Failf("partial output leaked", "%q: %x", tc.src, buf)
+				break
+			}
+		}
+
+		// the first symbols of every input decode to "12345"
+		is.NotContains(string(buf), "12345", tc.src)
+
+		b, err = tc.enc.AppendDecodeStringAtomic(dst, tc.src)
+		is.NotNil(err, tc.src)
+		is.Equal("test_", string(b), tc.src)
+		is.NotContains(string(buf), "12345", tc.src)
+
+		// a destination that must grow keeps its contents
+		dst = []byte("test_")
+
+		b, err = tc.enc.AppendDecodeAtomic(dst, []byte(tc.src))
+		is.NotNil(err, tc.src)
+		is.Equal("test_", string(b), tc.src)
+		is.Equal(len(dst), len(b), tc.src)
+	}
+}
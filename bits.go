@@ -0,0 +1,216 @@
+package base32
+
+import (
+	"slices"
+	"unsafe"
+)
+
+// EncodeBits returns the Crockford encoded form of the first nbits bits
+// of src.
+//
+// See Encoding.EncodeBits for details.
+func EncodeBits(src []byte, nbits int) []byte {
+	return Crockford.EncodeBits(src, nbits)
+}
+
+// AppendEncodeBits returns the Crockford encoded form of the first nbits
+// bits of src appended to dst.
+//
+// See Encoding.EncodeBits for details.
+func AppendEncodeBits(dst, src []byte, nbits int) []byte {
+	return Crockford.AppendEncodeBits(dst, src, nbits)
+}
+
+// DecodeBits returns the Crockford decoded bits of src.
+//
+// See Encoding.DecodeBits for details.
+func DecodeBits(src []byte) ([]byte, int, error) {
+	return Crockford.DecodeBits(src)
+}
+
+// DecodeStringBits returns the Crockford decoded bits of src.
+//
+// See Encoding.DecodeBits for details.
+func DecodeStringBits(src string) ([]byte, int, error) {
+	return Crockford.DecodeStringBits(src)
+}
+
+// EncodeBits returns nil if nbits is zero, otherwise it returns the
+// encoded form of the first nbits bits of src, most significant bit
+// first, as exactly (nbits+4)/5 symbols. Bits of src beyond nbits are
+// ignored and the unused bits of the final symbol are zero.
+//
+// The result is never padded, even if enc is, as a symbol count that is
+// not a whole number of bytes can't be expressed with padding.
+//
+// This function panics if nbits is negative or greater than
+// len(src)*8.
+func (enc *Encoding) EncodeBits(src []byte, nbits int) []byte {
+	if nbits == 0 {
+		return nil
+	}
+
+	return enc.AppendEncodeBits(nil, src, nbits)
+}
+
+// AppendEncodeBits returns the encoded form of the first nbits bits of
+// src appended to dst. If nbits is zero dst is returned as-is.
+//
+// See EncodeBits for details.
+func (enc *Encoding) AppendEncodeBits(dst, src []byte, nbits int) []byte {
+	if nbits < 0 || nbits > len(src)*8 {
+		panic("base32: invalid bit length")
+	}
+
+	if nbits == 0 {
+		return dst
+	}
+
+	k := (nbits + 4) / 5
+	orig := len(dst)
+
+	dst = slices.Grow(dst, k)
+	dst = dst[:orig+k]
+
+	// Whole 40 bit groups are encoded in place.
+	full := nbits / 40 * 5
+	if full > 0 {
+		enc.encode(unsafe.Pointer(&dst[orig]), unsafe.Pointer(&src[0]), full)
+	}
+
+	rem := nbits - full*8
+	if rem == 0 {
+		return dst
+	}
+
+	// The remaining bits are masked and encoded through scratch buffers
+	// so the kernel never emits more symbols, or padding, than needed.
+	var in [5]byte
+	var out [8]byte
+
+	n := copy(in[:], src[full:full+(rem+7)/8])
+	in[n-1] &= byte(0xFF << (n*8 - rem))
+
+	enc.encode(unsafe.Pointer(&out[0]), unsafe.Pointer(&in[0]), n)
+
+	copy(dst[orig+full/5*8:], out[:])
+
+	return dst
+}
+
+// DecodeBits returns the decoded form of src along with the number of
+// bits it holds, which is 5 for every symbol. The bits are returned most
+// significant bit first in (nbits+7)/8 bytes with any bits beyond nbits
+// set to zero. If src is empty nil, 0, nil is returned.
+//
+// Unlike Decode every number of symbols is valid and there are no tail
+// bits to check. Padding is not accepted. Separators, if enc has any,
+// are skipped.
+//
+// If an error is returned the caller must not assume the returned slice
+// is nil. See Decode for details.
+func (enc *Encoding) DecodeBits(src []byte) ([]byte, int, error) {
+	if len(src) == 0 {
+		return nil, 0, nil
+	}
+
+	return enc.decodeBits(unsafe.Pointer(&src[0]), len(src))
+}
+
+// DecodeStringBits returns the decoded form of src along with the number
+// of bits it holds.
+//
+// See DecodeBits for details.
+func (enc *Encoding) DecodeStringBits(src string) ([]byte, int, error) {
+	if len(src) == 0 {
+		return nil, 0, nil
+	}
+
+	return enc.decodeBits(unsafe.Pointer(unsafe.StringData(src)), len(src))
+}
+
+// decodeBits decodes the n bytes of encoded input at srcPtr as a bit
+// string.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) decodeBits(srcPtr unsafe.Pointer, n int) ([]byte, int, error) {
+	sep := enc.sepTab
+
+	k := n
+	if sep != nil {
+		k = 0
+		for i := range n {
+			if !sep[*(*byte)(unsafe.Add(srcPtr, i))] {
+				k++
+			}
+		}
+
+		if k == 0 {
+			return nil, 0, nil
+		}
+	}
+
+	nbits := k * 5
+	dst := make([]byte, (nbits+7)/8)
+	dstPtr := unsafe.Pointer(&dst[0])
+
+	// Symbols are gathered into groups of 8 as in decodeSeparated. Input
+	// without separators has its whole groups decoded in place.
+	var group [8]byte
+	var groupOff [8]int // input offsets of the symbols in group
+	var g int
+
+	i := 0
+	if sep == nil {
+		i = k / 8 * 8
+		if i > 0 {
+			if err := enc.decode(dstPtr, srcPtr, i); err != nil {
+				return dst, 0, err
+			}
+
+			dstPtr = unsafe.Add(dstPtr, i/8*5)
+		}
+	}
+
+	for ; i < n; i++ {
+		c := *(*byte)(unsafe.Add(srcPtr, i))
+		if sep != nil && sep[c] {
+			continue
+		}
+
+		group[g] = c
+		groupOff[g] = i
+		g++
+
+		if g == len(group) {
+			if err := enc.decode(dstPtr, unsafe.Pointer(&group[0]), g); err != nil {
+				return dst, 0, groupError(err, &groupOff)
+			}
+
+			dstPtr = unsafe.Add(dstPtr, 5)
+			g = 0
+		}
+	}
+
+	if g == 0 {
+		return dst, nbits, nil
+	}
+
+	// The remaining symbols are completed with zero valued symbols so
+	// the kernel decodes a whole group without checking tail bits.
+	var out [5]byte
+
+	for j := g; j < len(group); j++ {
+		group[j] = enc.encodeTab[0]
+	}
+
+	if err := enc.decode(unsafe.Pointer(&out[0]), unsafe.Pointer(&group[0]), len(group)); err != nil {
+		return dst, 0, groupError(err, &groupOff)
+	}
+
+	copy(unsafe.Slice((*byte)(dstPtr), (g*5+7)/8), out[:])
+
+	return dst, nbits, nil
+}
@@ -0,0 +1,164 @@
+package base32
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeBits(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	src := []byte("1234567890123456789")
+
+	// reference: the value of the first nbits bits of src
+	bitsValue := func(nbits int) *big.Int {
+		v := new(big.Int).SetBytes(src)
+		return v.Rsh(v, uint(len(src)*8-nbits))
+	}
+
+	for _, enc := range []*Encoding{Crockford, Crockford.Strict(), StdEncoding, RawHexEncoding.ConstantTime()} {
+		for nbits := range len(src)*8 + 1 {
+			s := enc.EncodeBits(src, nbits)
+			if nbits == 0 {
+				is.Nil(s)
+			}
+
+			is.Len(s, (nbits+4)/5)
+			is.Equal("test_"+string(s), string(enc.AppendEncodeBits([]byte("test_"), src, nbits)))
+
+			// the symbols are the value shifted left to a multiple of 5
+			// bits
+			v := new(big.Int).Lsh(bitsValue(nbits), uint(len(s)*5-nbits))
+			for i := len(s) - 1; i >= 0; i-- {
+				is.Equal(enc.encodeTab[v.Uint64()&31], s[i], nbits)
+				v.Rsh(v, 5)
+			}
+
+			// decoding returns the bits left aligned in whole bytes
+			b, n, err := enc.DecodeBits(s)
+			is.Nil(err)
+			is.Equal(len(s)*5, n)
+			is.Len(b, (n+7)/8)
+
+			exp := new(big.Int).Lsh(bitsValue(nbits), uint(len(b)*8-nbits))
+			is.Zero(exp.Cmp(new(big.Int).SetBytes(b)), nbits)
+
+			b2, n2, err := enc.DecodeStringBits(string(s))
+			is.Nil(err)
+			is.Equal(n, n2)
+			is.Equal(b, b2)
+
+			// whole bytes round trip through the regular decoder
+			if nbits%40 == 0 {
+				is.Equal(enc.WithPadding(NoPadding).Encode(src[:nbits/8]), s)
+			}
+		}
+
+		is.PanicsWithValue("base32: invalid bit length", func() {
+			enc.EncodeBits(src, -1)
+		})
+		is.PanicsWithValue("base32: invalid bit length", func() {
+			enc.EncodeBits(src, len(src)*8+1)
+		})
+	}
+
+	// bits beyond nbits are ignored
+	is.Equal("ZY", string(EncodeBits([]byte{0xFF, 0xFF}, 9)))
+	is.Equal("ZZZZZZZZG", string(EncodeBits([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, 41)))
+	is.Equal("test_ZY", string(AppendEncodeBits([]byte("test_"), []byte{0xFF, 0xFF}, 9)))
+
+	// a 130 bit value uses 26 symbols
+	s := EncodeBits(make([]byte, 17), 130)
+	is.Equal(strings.Repeat("0", 26), string(s))
+
+	b, n, err := DecodeBits(s)
+	is.Nil(err)
+	is.Equal(130, n)
+	is.Equal(make([]byte, 17), b)
+
+	b, n, err = DecodeStringBits("ZZZ")
+	is.Nil(err)
+	is.Equal(15, n)
+	is.Equal([]byte{0xFF, 0xFE}, b)
+
+	b, n, err = DecodeBits(nil)
+	is.Nil(err)
+	is.Nil(b)
+	is.Zero(n)
+
+	b, n, err = DecodeStringBits("")
+	is.Nil(err)
+	is.Nil(b)
+	is.Zero(n)
+
+	// separators are skipped
+	hyphens := Crockford.WithSeparators("-")
+
+	b, n, err = hyphens.DecodeStringBits("ZZZZ-ZZZZ-Z")
+	is.Nil(err)
+	is.Equal(45, n)
+	is.Equal([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xF8}, b)
+
+	b, n, err = hyphens.DecodeStringBits("--")
+	is.Nil(err)
+	is.Nil(b)
+	is.Zero(n)
+
+	// separators anywhere decode like the input without them
+	r := rand.New(rand.NewPCG(17, 18))
+	for range 200 {
+		var plain, sep []byte
+		for range r.IntN(30) {
+			c := crockfordAlphabet[r.IntN(32)]
+			plain = append(plain, c)
+			sep = append(sep, c)
+			for r.IntN(3) == 0 {
+				sep = append(sep, '-')
+			}
+		}
+
+		expB, expN, expErr := Crockford.DecodeBits(plain)
+		b, n, err := hyphens.DecodeBits(sep)
+		is.Nil(expErr)
+		is.Nil(err, "%s", sep)
+		is.Equal(expN, n, "%s", sep)
+		is.Equal(expB, b, "%s", sep)
+	}
+
+	for _, tc := range []struct {
+		enc    *Encoding
+		src    string
+		char   byte
+		offset int64
+	}{
+		{Crockford, "U", 'U', 0},
+		{Crockford, "0000000U", 'U', 7},
+		{Crockford, "000000000U", 'U', 9},
+		{hyphens, "0000-0000-0U", 'U', 11},
+		{hyphens, "0000-00U0-00", 'U', 7},
+		{hyphens, "-0-0-0-0-0-0-0-U-0", 'U', 15},
+		{StdEncoding, "MY======", '=', 2},
+	} {
+		_, _, err := tc.enc.DecodeStringBits(tc.src)
+		is.Equal(&DecodeError{Offset: tc.offset, Char: tc.char, Kind: InvalidChar}, err, tc.src)
+	}
+}
+
+// TestDecodeBitsAllocs is not parallel as AllocsPerRun requires it.
+func TestDecodeBitsAllocs(t *testing.T) {
+	is := assert.New(t)
+
+	hyphens := Crockford.WithSeparators("-")
+
+	// separators are skipped without copying the input
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _, _ = hyphens.DecodeStringBits("64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GE8")
+	})
+	is.Equal(1.0, allocs)
+}
@@ -0,0 +1,216 @@
+package base32
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// The Crockford specification defines an optional check symbol that
+// follows the encoded symbols. Its value is the number represented by
+// the symbols modulo 37, which is why five extra symbols beyond the 32
+// of the alphabet are needed.
+
+const checkSymbols = crockfordAlphabet + "*~$=U"
+
+var ErrInvalidBase32Checksum = errors.New("invalid base32 checksum")
+
+// checkValue returns the value of the check symbol c or b32Invalid if c
+// is not a check symbol.
+func checkValue(c byte) byte {
+	if v := Crockford.decodeTab[c]; v != b32Invalid {
+		return v
+	}
+
+	switch c {
+	case '*':
+		return 32
+	case '~':
+		return 33
+	case '$':
+		return 34
+	case '=':
+		return 35
+	case 'U', 'u':
+		return 36
+	}
+
+	return b32Invalid
+}
+
+// checksum returns the value modulo 37 of the number represented by
+// the n valid Crockford symbols found at srcPtr.
+func checksum(srcPtr unsafe.Pointer, n int) byte {
+	tab := &Crockford.decodeTab
+
+	var result uint32
+	for i := range n {
+		result = (result<<5 | uint32(tab[*(*byte)(unsafe.Add(srcPtr, i))])) % 37
+	}
+
+	return byte(result)
+}
+
+// appendCheck appends the check symbol for the encoded symbols found in
+// dst after the first orig bytes.
+func appendCheck(dst []byte, orig int) []byte {
+	n := len(dst) - orig
+
+	return append(dst, checkSymbols[checksum(unsafe.Pointer(&dst[orig]), n)])
+}
+
+// verifyCheck returns an error if the final symbol of the n bytes found
+// at srcPtr is not the check symbol of the symbols that precede it.
+//
+// invariants:
+//
+// - n > 1
+//
+// - the symbols preceding the check symbol are valid
+func verifyCheck(srcPtr unsafe.Pointer, n int) error {
+	c := *(*byte)(unsafe.Add(srcPtr, n-1))
+
+	v := checkValue(c)
+	if v == b32Invalid {
+		return &DecodeError{Offset: int64(n - 1), Char: c, Kind: InvalidChar}
+	}
+
+	if v != checksum(srcPtr, n-1) {
+		return &DecodeError{Offset: int64(n - 1), Char: c, Kind: InvalidChecksum}
+	}
+
+	return nil
+}
+
+// EncodeWithCheck returns nil if src is empty, otherwise it returns the
+// Crockford encoded form of src followed by its check symbol.
+func EncodeWithCheck(src []byte) []byte {
+	if len(src) == 0 {
+		return nil
+	}
+
+	return AppendEncodeWithCheck(nil, src)
+}
+
+// EncodeStringWithCheck returns "" if src is empty, otherwise it returns
+// the Crockford encoded form of src followed by its check symbol.
+func EncodeStringWithCheck(src string) string {
+	if len(src) == 0 {
+		return ""
+	}
+
+	return string(AppendEncodeStringWithCheck(nil, src))
+}
+
+// AppendEncodeWithCheck returns the Crockford encoded form of src
+// followed by its check symbol appended to dst if src is not empty. If
+// src is empty dst is returned as-is.
+func AppendEncodeWithCheck(dst, src []byte) []byte {
+	if len(src) == 0 {
+		return dst
+	}
+
+	orig := len(dst)
+
+	return appendCheck(Crockford.AppendEncode(dst, src), orig)
+}
+
+// AppendEncodeStringWithCheck returns the Crockford encoded form of src
+// followed by its check symbol appended to dst if src is not empty. If
+// src is empty dst is returned as-is.
+func AppendEncodeStringWithCheck(dst []byte, src string) []byte {
+	if len(src) == 0 {
+		return dst
+	}
+
+	orig := len(dst)
+
+	return appendCheck(Crockford.AppendEncodeString(dst, src), orig)
+}
+
+// DecodeWithCheck returns the Crockford decoded form of src after
+// verifying and removing its trailing check symbol. If src is empty nil
+// is returned.
+//
+// If the check symbol does not match the decoded value then an
+// InvalidChecksum *DecodeError is returned.
+//
+// If an error is returned the caller must not assume the returned slice
+// is nil. See Decode for details.
+func DecodeWithCheck(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	return AppendDecodeWithCheck(nil, src)
+}
+
+// DecodeStringWithCheck returns the Crockford decoded form of src after
+// verifying and removing its trailing check symbol. If src is empty nil
+// is returned.
+//
+// If the check symbol does not match the decoded value then an
+// InvalidChecksum *DecodeError is returned.
+//
+// If an error is returned the caller must not assume the returned slice
+// is nil. See Decode for details.
+func DecodeStringWithCheck(src string) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	return AppendDecodeStringWithCheck(nil, src)
+}
+
+// AppendDecodeWithCheck returns the Crockford decoded form of src
+// appended to dst after verifying and removing its trailing check
+// symbol. If src is empty dst is returned as-is.
+//
+// If the check symbol does not match the decoded value then an
+// InvalidChecksum *DecodeError is returned.
+//
+// If an error is returned the caller must not assume the returned slice
+// is nil. See AppendDecode for details.
+func AppendDecodeWithCheck(dst, src []byte) ([]byte, error) {
+	n := len(src)
+	if n == 0 {
+		return dst, nil
+	}
+
+	if n == 1 {
+		return nil, &DecodeError{Offset: 1, Kind: InvalidLength}
+	}
+
+	dst, err := Crockford.AppendDecode(dst, src[:n-1])
+	if err != nil {
+		return dst, err
+	}
+
+	return dst, verifyCheck(unsafe.Pointer(&src[0]), n)
+}
+
+// AppendDecodeStringWithCheck returns the Crockford decoded form of src
+// appended to dst after verifying and removing its trailing check
+// symbol. If src is empty dst is returned as-is.
+//
+// If the check symbol does not match the decoded value then an
+// InvalidChecksum *DecodeError is returned.
+//
+// If an error is returned the caller must not assume the returned slice
+// is nil. See AppendDecode for details.
+func AppendDecodeStringWithCheck(dst []byte, src string) ([]byte, error) {
+	n := len(src)
+	if n == 0 {
+		return dst, nil
+	}
+
+	if n == 1 {
+		return nil, &DecodeError{Offset: 1, Kind: InvalidLength}
+	}
+
+	dst, err := Crockford.AppendDecodeString(dst, src[:n-1])
+	if err != nil {
+		return dst, err
+	}
+
+	return dst, verifyCheck(unsafe.Pointer(unsafe.StringData(src)), n)
+}
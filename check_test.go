@@ -0,0 +1,132 @@
+package base32
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSymbol(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const src = "1234567890123456789"
+
+	for i := range len(src) + 1 {
+		src := src[:i]
+
+		s := EncodeStringWithCheck(src)
+		if i == 0 {
+			is.Equal("", s)
+			is.Nil(EncodeWithCheck([]byte(src)))
+			continue
+		}
+
+		enc := EncodeString(src)
+		is.Equal(enc, s[:len(s)-1])
+
+		// the check symbol is the value of the symbols modulo 37
+		v := new(big.Int)
+		for _, c := range []byte(enc) {
+			v.Lsh(v, 5)
+			v.Or(v, big.NewInt(int64(Crockford.decodeTab[c])))
+		}
+		is.Equal(checkSymbols[new(big.Int).Mod(v, big.NewInt(37)).Int64()], s[len(s)-1])
+
+		is.Equal(s, string(EncodeWithCheck([]byte(src))))
+		is.Equal("test_"+s, string(AppendEncodeWithCheck([]byte("test_"), []byte(src))))
+		is.Equal("test_"+s, string(AppendEncodeStringWithCheck([]byte("test_"), src)))
+
+		b, err := DecodeStringWithCheck(s)
+		is.Nil(err)
+		is.Equal(src, string(b))
+
+		b, err = DecodeWithCheck([]byte(s))
+		is.Nil(err)
+		is.Equal(src, string(b))
+
+		b, err = AppendDecodeWithCheck([]byte("test_"), []byte(s))
+		is.Nil(err)
+		is.Equal("test_"+src, string(b))
+
+		b, err = AppendDecodeStringWithCheck([]byte("test_"), s)
+		is.Nil(err)
+		is.Equal("test_"+src, string(b))
+
+		// every single symbol substitution is detected
+		for j := range len(s) - 1 {
+			for k := range 32 {
+				bad := []byte(s)
+				if bad[j] == Crockford.encodeTab[k] {
+					continue
+				}
+				bad[j] = Crockford.encodeTab[k]
+
+				_, err := DecodeWithCheck(bad)
+				is.Error(err)
+				if !errors.Is(err, ErrInvalidBase32Char) {
+					is.ErrorIs(err, ErrInvalidBase32Checksum)
+				}
+			}
+		}
+	}
+
+	// every check symbol decodes, including lowercase and aliases
+	for c, v := range map[byte]byte{
+		'0': 0, 'o': 0, 'I': 1, 'l': 1, 'z': 31,
+		'*': 32, '~': 33, '$': 34, '=': 35, 'U': 36, 'u': 36,
+	} {
+		is.Equal(v, checkValue(c))
+	}
+	is.Equal(byte(b32Invalid), checkValue('#'))
+
+	for _, c := range []struct {
+		src string
+		err error
+	}{
+		{"0", ErrInvalidBase32Length},
+		{"0000", ErrInvalidBase32Length},
+		{"0U0", ErrInvalidBase32Char},
+		{"00#", ErrInvalidBase32Char},
+		{"001", ErrInvalidBase32Checksum},
+		{"10*", nil},
+		{"C4J", nil},
+		{"1o*", nil},
+	} {
+		_, err := DecodeStringWithCheck(c.src)
+		if c.err == nil {
+			is.Nil(err, c.src)
+		} else {
+			is.ErrorIs(err, c.err, c.src)
+		}
+
+		_, err = AppendDecodeWithCheck(nil, []byte(c.src))
+		if c.err == nil {
+			is.Nil(err, c.src)
+		} else {
+			is.ErrorIs(err, c.err, c.src)
+		}
+	}
+
+	b, err := DecodeWithCheck(nil)
+	is.Nil(err)
+	is.Nil(b)
+
+	b, err = DecodeStringWithCheck("")
+	is.Nil(err)
+	is.Nil(b)
+
+	is.Equal("test_", string(AppendEncodeWithCheck([]byte("test_"), nil)))
+	is.Equal("test_", string(AppendEncodeStringWithCheck([]byte("test_"), "")))
+
+	b, err = AppendDecodeWithCheck([]byte("test_"), nil)
+	is.Nil(err)
+	is.Equal("test_", string(b))
+
+	b, err = AppendDecodeStringWithCheck([]byte("test_"), "")
+	is.Nil(err)
+	is.Equal("test_", string(b))
+}
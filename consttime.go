@@ -0,0 +1,175 @@
+package base32
+
+import (
+	"crypto/subtle"
+	"unsafe"
+)
+
+// ConstantTime creates a new encoding identical to enc except that its
+// encoder and decoder avoid memory accesses and branches that depend on
+// the data being processed, for use with keys, tokens, and other secret
+// material.
+//
+// Instead of indexing lookup tables by secret values every symbol is
+// compared against every entry of the alphabet (or against every byte
+// accepted by the decoder) and the matching entry is selected with a
+// mask. The whole input is always processed before an error is
+// reported. This makes encoding and decoding several times slower.
+//
+// The tail bit functions select the symbol holding the tail bits the
+// same way. Timing still depends on the length of the data, on the
+// amount of padding, and on whether the input is valid. Once decoding has failed
+// the returned *DecodeError is built without those protections. Valid,
+// ValidString, and their Len variants use the constant-time decoder
+// too; Normalize and IsCanonical do not.
+//
+// This function panics if enc has separators, as skipping them depends
+// on the input. WithSeparators likewise panics for a constant-time
+// encoding.
+func (enc *Encoding) ConstantTime() *Encoding {
+	if enc.sepTab != nil {
+		panic("base32: constant-time encoding can not have separators")
+	}
+
+	result := *enc
+	result.constantTime = true
+
+	return &result
+}
+
+// ctPair is a byte accepted by a decoder and the value it decodes to.
+type ctPair struct {
+	c, v byte
+}
+
+// ctMask returns 0xFF if a == b and 0 otherwise without branching.
+func ctMask(a, b byte) byte {
+	return byte(-subtle.ConstantTimeByteEq(a, b))
+}
+
+// ctSymbol returns the symbol of the 5 bit value v without indexing the
+// alphabet by v.
+func (enc *Encoding) ctSymbol(v byte) byte {
+	var result byte
+	for i, c := range enc.encodeTab {
+		result |= c & ctMask(byte(i), v)
+	}
+
+	return result
+}
+
+// ctValue returns the entry of the decode table for c without indexing
+// the table by c.
+func (enc *Encoding) ctValue(c byte) byte {
+	var result byte
+	for i, v := range enc.decodeTab {
+		result |= v & ctMask(byte(i), c)
+	}
+
+	return result
+}
+
+// encodeConstantTime is the constant-time equivalent of encode.
+func (enc *Encoding) encodeConstantTime(dstPtr, srcPtr unsafe.Pointer, n int) {
+	for i := 0; i < n; i += 5 {
+		var b [5]byte
+		copy(b[:], unsafe.Slice((*byte)(unsafe.Add(srcPtr, i)), min(5, n-i)))
+
+		vals := [8]byte{
+			b[0] >> 3,
+			((b[0] << 2) | (b[1] >> 6)) & 31,
+			(b[1] >> 1) & 31,
+			((b[1] << 4) | (b[2] >> 4)) & 31,
+			((b[2] << 1) | (b[3] >> 7)) & 31,
+			(b[3] >> 2) & 31,
+			((b[3] << 3) | (b[4] >> 5)) & 31,
+			b[4] & 31,
+		}
+
+		k := 8
+		if n-i < 5 {
+			k = ((n-i)*8 + 4) / 5
+		}
+
+		for j := range k {
+			*(*byte)(unsafe.Add(dstPtr, j)) = enc.ctSymbol(vals[j])
+		}
+
+		if k < 8 && enc.padChar != NoPadding {
+			for j := k; j < 8; j++ {
+				*(*byte)(unsafe.Add(dstPtr, j)) = byte(enc.padChar)
+			}
+		}
+
+		dstPtr = unsafe.Add(dstPtr, 8)
+	}
+}
+
+// decodeConstantTime is the constant-time equivalent of decode. If
+// dstPtr is nil the input is only validated.
+func (enc *Encoding) decodeConstantTime(dstPtr, srcPtr unsafe.Pointer, n int) error {
+	// The set of accepted bytes is public so it is gathered up front,
+	// leaving a list every symbol can be compared against.
+	var pairs [256]ctPair
+	var np int
+	for i, v := range enc.decodeTab {
+		if v != b32Invalid {
+			pairs[np] = ctPair{byte(i), v}
+			np++
+		}
+	}
+
+	var bad, badChar, last byte
+	var badOff int
+
+	for i := 0; i < n; i += 8 {
+		k := min(8, n-i)
+
+		var vals [8]byte
+		for j := range k {
+			c := *(*byte)(unsafe.Add(srcPtr, i+j))
+
+			var v, ok byte
+			for _, p := range pairs[:np] {
+				m := ctMask(p.c, c)
+				v |= p.v & m
+				ok |= m
+			}
+
+			// Remember the first byte that is not accepted.
+			first := ^ok &^ bad
+			badOff |= (i + j) & -int(first&1)
+			badChar |= c & first
+			bad |= ^ok
+
+			vals[j] = v
+			last = v
+		}
+
+		if dstPtr == nil {
+			continue
+		}
+
+		out := [5]byte{
+			vals[0]<<3 | vals[1]>>2,
+			(vals[1]&0x03)<<6 | vals[2]<<1 | vals[3]>>4,
+			(vals[3]&0x0F)<<4 | vals[4]>>1,
+			(vals[4]&0x01)<<7 | vals[5]<<2 | vals[6]>>3,
+			(vals[6]&0x07)<<5 | vals[7],
+		}
+
+		copy(unsafe.Slice((*byte)(dstPtr), k*5/8), out[:])
+
+		dstPtr = unsafe.Add(dstPtr, 5)
+	}
+
+	if bad != 0 {
+		return enc.charError(badChar, badOff)
+	}
+
+	if last&tailMask[n%8]&enc.tailCheck() != 0 {
+		return &DecodeError{Offset: int64(n - 1), Char: *(*byte)(unsafe.Add(srcPtr, n-1)), Kind: NonCanonicalTail}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package base32
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantTime(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for _, enc := range []*Encoding{Crockford, Crockford.Strict(), StdEncoding, RawHexEncoding, Crockford.RightAligned()} {
+		ct := enc.ConstantTime()
+
+		for n := range 42 {
+			src := make([]byte, n)
+			for i := range src {
+				src[i] = byte(r.Uint32())
+			}
+
+			// encoding matches the table driven encoder
+			exp := enc.Encode(src)
+			s := ct.Encode(src)
+			is.Equal(exp, s)
+			is.Equal(string(exp), ct.EncodeString(string(src)))
+			is.Equal(append([]byte("test_"), exp...), ct.AppendEncode([]byte("test_"), src))
+
+			// decoding matches the table driven decoder, including for
+			// input mangled with bytes that are not symbols, aliases,
+			// lowercase letters, or non-canonical tails
+			for range 8 {
+				in := bytes.Clone(s)
+				if len(in) > 0 && r.IntN(2) == 0 {
+					in[r.IntN(len(in))] = "0oOlLiI1aU*_=zZ"[r.IntN(15)]
+				}
+
+				expDec, expErr := enc.Decode(in)
+				dec, err := ct.Decode(in)
+				is.Equal(expErr, err, "%q", in)
+
+				if expErr == nil {
+					is.Equal(expDec, dec, "%q", in)
+				}
+
+				is.Equal(expErr, ct.Valid(in), "%q", in)
+			}
+
+			if enc.rightAligned {
+				continue
+			}
+
+			// the tail bit functions select symbols with masks too
+			bits := uint8(r.UintN(1 << TailBits(n)))
+			s = ct.EncodeWithTailBits(src, bits)
+			is.Equal(enc.EncodeWithTailBits(src, bits), s)
+
+			dec, got, nbits, err := ct.DecodeTailBits(s)
+			is.Nil(err)
+			is.Equal(bits, got)
+			is.Equal(TailBits(n), nbits)
+			if n > 0 {
+				is.Equal(src, dec)
+			}
+		}
+
+		// the masked lookups match the tables
+		for v := range 32 {
+			is.Equal(ct.encodeTab[v], ct.ctSymbol(byte(v)))
+		}
+		for c := range 256 {
+			is.Equal(ct.decodeTab[c], ct.ctValue(byte(c)))
+		}
+	}
+
+	// the tail bits of an alias are those of its symbol
+	_, bits, _, err := Crockford.ConstantTime().DecodeStringTailBits("Zo")
+	is.Nil(err)
+	is.Equal(uint8(0), bits)
+
+	_, bits, _, err = Crockford.ConstantTime().DecodeStringTailBits("ZL")
+	is.Nil(err)
+	is.Equal(uint8(1), bits)
+
+	// the streaming encoder and decoder use the constant-time kernels
+	ct := Crockford.ConstantTime()
+	src := streamTestData(1000)
+
+	var buf bytes.Buffer
+	w := ct.NewEncoder(&buf)
+	_, err = w.Write(src)
+	is.Nil(err)
+	is.Nil(w.Close())
+	is.Equal(EncodeString(string(src)), buf.String())
+
+	b, err := io.ReadAll(ct.NewDecoder(&buf))
+	is.Nil(err)
+	is.Equal(src, b)
+
+	// every input byte is examined even after a failure is found
+	_, err = ct.DecodeString("0U00000U")
+	is.Equal(&DecodeError{Offset: 1, Char: 'U', Kind: InvalidChar}, err)
+	_, err = ct.Strict().DecodeString("00000o0U")
+	is.Equal(&DecodeError{Offset: 5, Char: 'o', Kind: NonCanonicalChar}, err)
+	_, err = ct.DecodeString("00000001")
+	is.Nil(err)
+	_, err = ct.DecodeString("01")
+	is.Equal(&DecodeError{Offset: 1, Char: '1', Kind: NonCanonicalTail}, err)
+
+	// including a failure in the head of a right-aligned value, after
+	// which the remaining groups are still decoded
+	ra := Crockford.RightAligned()
+	for _, tc := range []struct {
+		src string
+		err error
+	}{
+		{"U00000000000000000ZZZZZZZZ", &DecodeError{Offset: 0, Char: 'U', Kind: InvalidChar}},
+		{"8Z0000000000000000ZZZZZZZZ", &DecodeError{Offset: 0, Char: '8', Kind: Overflow}},
+		{"0U0000000000000000ZZZZZZZU", &DecodeError{Offset: 1, Char: 'U', Kind: InvalidChar}},
+	} {
+		for _, enc := range []*Encoding{ra, ra.ConstantTime()} {
+			_, err := enc.DecodeString(tc.src)
+			is.Equal(tc.err, err, tc.src)
+			is.Equal(tc.err, enc.ValidString(tc.src), tc.src)
+
+			dst := make([]byte, 16)
+			is.Equal(tc.err, enc.UnsafeDecode(dst, []byte(tc.src)), tc.src)
+
+			if strings.HasSuffix(tc.src, "U") {
+				continue
+			}
+
+			exp := make([]byte, 5)
+			if enc.constantTime {
+				exp = bytes.Repeat([]byte{0xFF}, 5)
+			}
+			is.Equal(exp, dst[11:], tc.src)
+		}
+	}
+
+	is.PanicsWithValue("base32: constant-time encoding can not have separators", func() {
+		Crockford.WithSeparators("-").ConstantTime()
+	})
+	is.PanicsWithValue("base32: constant-time encoding can not have separators", func() {
+		ct.WithSeparators("-")
+	})
+	is.NotPanics(func() {
+		ct.WithSeparators("")
+	})
+}
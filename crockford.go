@@ -0,0 +1,100 @@
+package base32
+
+// EncodedLength returns the number of bytes required to
+// encode n bytes with the Crockford encoding.
+//
+// See Encoding.EncodedLength for details.
+func EncodedLength(n int) int {
+	return Crockford.EncodedLength(n)
+}
+
+// UnsafeEncode fills dst with the Crockford encoded form of src.
+//
+// See Encoding.UnsafeEncode for details and invariants.
+func UnsafeEncode(dst []byte, src []byte) {
+	Crockford.UnsafeEncode(dst, src)
+}
+
+// Encode returns nil if src is empty, otherwise it returns the
+// Crockford encoded form of src.
+func Encode(src []byte) []byte {
+	return Crockford.Encode(src)
+}
+
+// EncodeString returns "" if src is empty, otherwise it returns the
+// Crockford encoded form of src.
+func EncodeString(src string) string {
+	return Crockford.EncodeString(src)
+}
+
+// AppendEncode returns the Crockford encoded form of src appended to
+// dst if src is not empty. If src is empty dst is returned as-is.
+func AppendEncode(dst, src []byte) []byte {
+	return Crockford.AppendEncode(dst, src)
+}
+
+// AppendEncodeString returns the Crockford encoded form of src appended
+// to dst if src is not empty. If src is empty dst is returned as-is.
+func AppendEncodeString(dst []byte, src string) []byte {
+	return Crockford.AppendEncodeString(dst, src)
+}
+
+// DecodedLength returns the number of bytes required to
+// decode n bytes with the Crockford encoding.
+//
+// See Encoding.DecodedLength for details.
+func DecodedLength(n int) int {
+	return Crockford.DecodedLength(n)
+}
+
+// UnsafeDecode decodes the Crockford encoded source slice into the
+// destination slice.
+//
+// See Encoding.UnsafeDecode for details and invariants.
+func UnsafeDecode(dst []byte, src []byte) error {
+	return Crockford.UnsafeDecode(dst, src)
+}
+
+// Decode returns the Crockford decoded form of src if src is not empty.
+// If src is empty nil is returned.
+//
+// See Encoding.Decode for details on the state of the result when an
+// error is returned.
+func Decode(src []byte) ([]byte, error) {
+	return Crockford.Decode(src)
+}
+
+// DecodeString returns the Crockford decoded form of src if src is not
+// empty. If src is empty nil is returned.
+//
+// See Encoding.DecodeString for details on the state of the result when
+// an error is returned.
+func DecodeString(src string) ([]byte, error) {
+	return Crockford.DecodeString(src)
+}
+
+// AppendDecode returns the Crockford decoded form of src appended to dst
+// if src is not empty. If src is empty dst is returned as-is.
+//
+// See Encoding.AppendDecode for details on the state of the result when
+// an error is returned.
+func AppendDecode(dst, src []byte) ([]byte, error) {
+	return Crockford.AppendDecode(dst, src)
+}
+
+// AppendDecodeString returns the Crockford decoded form of src appended
+// to dst if src is not empty. If src is empty dst is returned as-is.
+//
+// See Encoding.AppendDecodeString for details on the state of the result
+// when an error is returned.
+func AppendDecodeString(dst []byte, src string) ([]byte, error) {
+	return Crockford.AppendDecodeString(dst, src)
+}
+
+// DecodeStringInto decodes the Crockford encoded src into dst, which the
+// decoded form of src must fill exactly.
+//
+// See Encoding.DecodeStringInto for details.
+func DecodeStringInto(dst []byte, src string) error {
+	return Crockford.DecodeStringInto(dst, src)
+}
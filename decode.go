@@ -9,7 +9,9 @@
 // encoded value full length. If there was concrete length metadata present as
 // part of the standard decoding process I would feel differently but I leave that
 // up to the caller to implement as they choose and to clear the tail bits as
-// needed.
+// needed. WithLenientTail exists only to consume data from encoders that leave
+// garbage in them, while EncodeWithTailBits and DecodeTailBits pack data into
+// them deliberately.
 
 package base32
 
@@ -28,22 +30,42 @@ const (
 )
 
 var (
-	ErrInvalidBase32Length = errors.New("invalid base32 length")
-	ErrInvalidBase32Char   = errors.New("invalid base32 character")
+	ErrInvalidBase32Length  = errors.New("invalid base32 length")
+	ErrInvalidBase32Char    = errors.New("invalid base32 character")
+	ErrInvalidBase32Padding = errors.New("invalid base32 padding")
+	ErrNonCanonicalTail     = errors.New("non-canonical base32 tail bits")
+	ErrNonCanonicalChar     = errors.New("non-canonical base32 character")
+	ErrOverflow             = errors.New("base32 leading symbol overflow")
 )
 
 // DecodedLength returns the number of bytes required to
 // decode n bytes. It returns -1 if the input byte length
 // cannot be decoded properly.
 //
+// If the encoding is padded the input length must be a
+// multiple of 8 and the result is the maximum number of bytes
+// the input could decode to. The exact length depends on the
+// number of trailing padding characters.
+//
+// If the encoding has separators then n must not count
+// them, as only the encoded symbols determine the length.
+//
 // If the input is zero, zero will be returned. Please
 // remember that UnsafeDecode requires the src argument
 // to have a length greater than zero.
-func DecodedLength(n int) int {
+func (enc *Encoding) DecodedLength(n int) int {
 	if n < 0 {
 		return -1
 	}
 
+	if enc.padChar != NoPadding {
+		if n%8 != 0 {
+			return -1
+		}
+
+		return (n / 8) * 5
+	}
+
 	return decodedLen(n)
 }
 
@@ -69,20 +91,192 @@ func decodedLen(n int) int {
 	return (n/8)*5 + (rem*5)/8
 }
 
-func decode(dstPtr, srcPtr unsafe.Pointer, n int) error {
+// decodeLen validates the length and any trailing padding of the n
+// bytes of encoded input found at srcPtr. It returns the number of
+// leading bytes that hold encoded symbols (and any separators between
+// them) as well as the number of bytes those symbols decode to.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) decodeLen(srcPtr unsafe.Pointer, n int) (int, int, error) {
+	symbols := n
+	if enc.sepTab != nil {
+		symbols = enc.countSymbols(srcPtr, n)
+	}
+
+	if enc.padChar == NoPadding {
+		size := decodedLen(symbols)
+		if size < 0 {
+			return 0, 0, &DecodeError{Offset: int64(n), Kind: InvalidLength}
+		}
+
+		return n, size, nil
+	}
+
+	if symbols%8 != 0 {
+		return 0, 0, &DecodeError{Offset: int64(n), Kind: InvalidLength}
+	}
+
+	pad := byte(enc.padChar)
+
+	m := n
+	pads := 0
+	padOff := 0
+	for m > 0 {
+		c := *(*byte)(unsafe.Add(srcPtr, m-1))
+
+		if c == pad {
+			pads++
+			padOff = m - 1
+
+			if pads == 8 {
+				break
+			}
+		} else if enc.sepTab == nil || !enc.sepTab[c] {
+			break
+		}
+
+		m--
+	}
+
+	// Only 0, 1, 3, 4, or 6 padding characters may follow the data
+	// and the count must be exactly what the data length implies.
+	symbols -= pads
+	if pads == 8 || (validDecodeRemainder&(uint8(1)<<(symbols%8))) == 0 {
+		return 0, 0, &DecodeError{Offset: int64(padOff), Kind: InvalidPadding}
+	}
+
+	return m, decodedLen(symbols), nil
+}
+
+// countSymbols returns the number of bytes found in the first n bytes
+// at srcPtr that are not separators.
+func (enc *Encoding) countSymbols(srcPtr unsafe.Pointer, n int) int {
+	sep := enc.sepTab
+
+	result := n
+	for i := range n {
+		if sep[*(*byte)(unsafe.Add(srcPtr, i))] {
+			result--
+		}
+	}
+
+	return result
+}
+
+// decodeInput decodes the first n bytes at srcPtr, skipping separators
+// if the encoding has any and honoring its alignment.
+func (enc *Encoding) decodeInput(dstPtr, srcPtr unsafe.Pointer, n int) error {
+	if enc.rightAligned {
+		return enc.decodeRightAligned(dstPtr, srcPtr, n)
+	}
+
+	if enc.sepTab != nil {
+		return enc.decodeSeparated(dstPtr, srcPtr, n)
+	}
+
+	return enc.decode(dstPtr, srcPtr, n)
+}
+
+// decodeSeparated decodes the first n bytes at srcPtr while skipping
+// separators. Symbols are gathered into groups of 8 so the strict length
+// and tail-bit rules of decode apply to them exactly as they would to
+// contiguous input. Runs of 8 symbols free of separators are decoded in
+// place.
+func (enc *Encoding) decodeSeparated(dstPtr, srcPtr unsafe.Pointer, n int) error {
+	sep := enc.sepTab
+
+	var group [8]byte
+	var groupOff [8]int // input offsets of the symbols in group
+	var k int
+
+	for i := 0; i < n; {
+		if k == 0 && n-i >= 8 {
+			run := 0
+			for run < 8 && !sep[*(*byte)(unsafe.Add(srcPtr, i+run))] {
+				run++
+			}
+
+			if run == 8 {
+				if err := enc.decode(dstPtr, unsafe.Add(srcPtr, i), 8); err != nil {
+					err.(*DecodeError).Offset += int64(i)
+					return err
+				}
+
+				dstPtr = unsafe.Add(dstPtr, 5)
+				i += 8
+				continue
+			}
+		}
+
+		c := *(*byte)(unsafe.Add(srcPtr, i))
+		i++
+
+		if sep[c] {
+			continue
+		}
+
+		group[k] = c
+		groupOff[k] = i - 1
+		k++
+
+		if k == len(group) {
+			if err := enc.decode(dstPtr, unsafe.Pointer(&group[0]), k); err != nil {
+				return groupError(err, &groupOff)
+			}
+
+			dstPtr = unsafe.Add(dstPtr, 5)
+			k = 0
+		}
+	}
+
+	if k == 0 {
+		return nil
+	}
+
+	if err := enc.decode(dstPtr, unsafe.Pointer(&group[0]), k); err != nil {
+		return groupError(err, &groupOff)
+	}
+
+	return nil
+}
+
+// groupError converts the offset within a group held by err, a
+// *DecodeError, into the input offset recorded for that symbol.
+func groupError(err error, groupOff *[8]int) error {
+	de := err.(*DecodeError)
+	de.Offset = int64(groupOff[de.Offset])
+
+	return err
+}
+
+// decode decodes the n symbols at srcPtr into dstPtr. The caller must
+// have validated n with decodedLen.
+//
+// Each group of symbols is validated at once by OR-ing the decoded
+// values together; decodeError locates the cause of a failure only once
+// one is detected, keeping the work off of the fast path.
+func (enc *Encoding) decode(dstPtr, srcPtr unsafe.Pointer, n int) error {
+	if enc.constantTime {
+		return enc.decodeConstantTime(dstPtr, srcPtr, n)
+	}
 
-	for range n / 8 {
-		c0 := decodeTab[*(*byte)(srcPtr)]
-		c1 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 1))]
-		c2 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 2))]
-		c3 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 3))]
-		c4 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 4))]
-		c5 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 5))]
-		c6 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 6))]
-		c7 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 7))]
+	tab := &enc.decodeTab
+	tm := enc.tailCheck()
+
+	for i := range n / 8 {
+		c0 := tab[*(*byte)(srcPtr)]
+		c1 := tab[*(*byte)(unsafe.Add(srcPtr, 1))]
+		c2 := tab[*(*byte)(unsafe.Add(srcPtr, 2))]
+		c3 := tab[*(*byte)(unsafe.Add(srcPtr, 3))]
+		c4 := tab[*(*byte)(unsafe.Add(srcPtr, 4))]
+		c5 := tab[*(*byte)(unsafe.Add(srcPtr, 5))]
+		c6 := tab[*(*byte)(unsafe.Add(srcPtr, 6))]
+		c7 := tab[*(*byte)(unsafe.Add(srcPtr, 7))]
 
 		if (c0 | c1 | c2 | c3 | c4 | c5 | c6 | c7) == b32Invalid {
-			return ErrInvalidBase32Char
+			return enc.decodeError(srcPtr, 8, i*8)
 		}
 
 		*(*byte)(dstPtr) = (c0<<3 | c1>>2)
@@ -98,55 +292,55 @@ func decode(dstPtr, srcPtr unsafe.Pointer, n int) error {
 	// Tail.
 	switch n % 8 {
 	case 2:
-		c0 := decodeTab[*(*byte)(srcPtr)]
-		c1 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 1))]
+		c0 := tab[*(*byte)(srcPtr)]
+		c1 := tab[*(*byte)(unsafe.Add(srcPtr, 1))]
 
 		// last 2 LSBs of last decoded value must be zero for remainder=2
-		if (c0|c1) == b32Invalid || (c1&0x03) != 0 {
-			return ErrInvalidBase32Char
+		if (c0|c1) == b32Invalid || (c1&0x03&tm) != 0 {
+			return enc.decodeError(srcPtr, 2, n/8*8)
 		}
 
 		*(*byte)(dstPtr) = (c0<<3 | c1>>2)
 	case 4:
-		c0 := decodeTab[*(*byte)(srcPtr)]
-		c1 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 1))]
-		c2 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 2))]
-		c3 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 3))]
+		c0 := tab[*(*byte)(srcPtr)]
+		c1 := tab[*(*byte)(unsafe.Add(srcPtr, 1))]
+		c2 := tab[*(*byte)(unsafe.Add(srcPtr, 2))]
+		c3 := tab[*(*byte)(unsafe.Add(srcPtr, 3))]
 
 		// last 4 LSBs of last decoded value must be zero for remainder=4
-		if (c0|c1|c2|c3) == b32Invalid || (c3&0x0F) != 0 {
-			return ErrInvalidBase32Char
+		if (c0|c1|c2|c3) == b32Invalid || (c3&0x0F&tm) != 0 {
+			return enc.decodeError(srcPtr, 4, n/8*8)
 		}
 
 		*(*byte)(dstPtr) = (c0<<3 | c1>>2)
 		*(*byte)(unsafe.Add(dstPtr, 1)) = ((c1&3)<<6 | c2<<1 | c3>>4)
 	case 5:
-		c0 := decodeTab[*(*byte)(srcPtr)]
-		c1 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 1))]
-		c2 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 2))]
-		c3 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 3))]
-		c4 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 4))]
+		c0 := tab[*(*byte)(srcPtr)]
+		c1 := tab[*(*byte)(unsafe.Add(srcPtr, 1))]
+		c2 := tab[*(*byte)(unsafe.Add(srcPtr, 2))]
+		c3 := tab[*(*byte)(unsafe.Add(srcPtr, 3))]
+		c4 := tab[*(*byte)(unsafe.Add(srcPtr, 4))]
 
 		// last 1 LSB of last decoded value must be zero for remainder=5
-		if (c0|c1|c2|c3|c4) == b32Invalid || (c4&0x01) != 0 {
-			return ErrInvalidBase32Char
+		if (c0|c1|c2|c3|c4) == b32Invalid || (c4&0x01&tm) != 0 {
+			return enc.decodeError(srcPtr, 5, n/8*8)
 		}
 
 		*(*byte)(dstPtr) = (c0<<3 | c1>>2)
 		*(*byte)(unsafe.Add(dstPtr, 1)) = ((c1&0x03)<<6 | c2<<1 | c3>>4)
 		*(*byte)(unsafe.Add(dstPtr, 2)) = ((c3&0x0F)<<4 | c4>>1)
 	case 7:
-		c0 := decodeTab[*(*byte)(srcPtr)]
-		c1 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 1))]
-		c2 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 2))]
-		c3 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 3))]
-		c4 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 4))]
-		c5 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 5))]
-		c6 := decodeTab[*(*byte)(unsafe.Add(srcPtr, 6))]
+		c0 := tab[*(*byte)(srcPtr)]
+		c1 := tab[*(*byte)(unsafe.Add(srcPtr, 1))]
+		c2 := tab[*(*byte)(unsafe.Add(srcPtr, 2))]
+		c3 := tab[*(*byte)(unsafe.Add(srcPtr, 3))]
+		c4 := tab[*(*byte)(unsafe.Add(srcPtr, 4))]
+		c5 := tab[*(*byte)(unsafe.Add(srcPtr, 5))]
+		c6 := tab[*(*byte)(unsafe.Add(srcPtr, 6))]
 
 		// last 3 LSBs of last decoded value must be zero for remainder=7
-		if (c0|c1|c2|c3|c4|c5|c6) == b32Invalid || (c6&0x07) != 0 {
-			return ErrInvalidBase32Char
+		if (c0|c1|c2|c3|c4|c5|c6) == b32Invalid || (c6&0x07&tm) != 0 {
+			return enc.decodeError(srcPtr, 7, n/8*8)
 		}
 
 		*(*byte)(dstPtr) = (c0<<3 | c1>>2)
@@ -158,6 +352,40 @@ func decode(dstPtr, srcPtr unsafe.Pointer, n int) error {
 	return nil
 }
 
+// decodeError returns a *DecodeError describing why the k symbols at
+// srcPtr, which begin at offset off of the input, failed to decode.
+//
+// invariants:
+//
+// - the symbols are either not all valid or the last holds non-zero
+// tail bits
+func (enc *Encoding) decodeError(srcPtr unsafe.Pointer, k, off int) error {
+	tab := &enc.decodeTab
+
+	for i := range k {
+		c := *(*byte)(unsafe.Add(srcPtr, i))
+
+		if tab[c] == b32Invalid {
+			return enc.charError(c, off+i)
+		}
+	}
+
+	c := *(*byte)(unsafe.Add(srcPtr, k-1))
+
+	return &DecodeError{Offset: int64(off + k - 1), Char: c, Kind: NonCanonicalTail}
+}
+
+// charError returns a *DecodeError for the byte c found at offset off of
+// the input which is not accepted by the decoder.
+func (enc *Encoding) charError(c byte, off int) error {
+	kind := InvalidChar
+	if enc.lenientTab != nil && enc.lenientTab[c] != b32Invalid {
+		kind = NonCanonicalChar
+	}
+
+	return &DecodeError{Offset: int64(off), Char: c, Kind: kind}
+}
+
 // UnsafeDecode decodes the source slice into the destination slice.
 //
 // It should generally only be used when working with pre-validated
@@ -171,7 +399,15 @@ func decode(dstPtr, srcPtr unsafe.Pointer, n int) error {
 //
 // Knowing the length of the slice now occupied by the decoded form of src
 // is the responsibility of the caller. It can easily be computed by the
-// expression ` (n/8)*5 + ((n%8)*5)/8` where n is the length of src.
+// expression ` (n/8)*5 + ((n%8)*5)/8` where n is the length of src
+// excluding any padding characters.
+//
+// If the encoding is padded and the padding does not match the length
+// of the data that precedes it then an InvalidPadding *DecodeError is
+// returned.
+//
+// If the encoding has separators they do not count towards the length
+// of src and this function panics if src holds no symbols.
 //
 // invariants:
 //
@@ -180,17 +416,28 @@ func decode(dstPtr, srcPtr unsafe.Pointer, n int) error {
 // - len(dst) >=  decodedLen(len(src))
 //
 // - len(src) is a valid base32 encoded value length
-func UnsafeDecode(dst []byte, src []byte) error {
+func (enc *Encoding) UnsafeDecode(dst []byte, src []byte) error {
 	// guard statements forcing panics rather than letting next call
 	// lead to undefined behaviors
 
-	if n := decodedLen(len(src)); n <= 0 {
+	if len(src) == 0 {
 		panic("base32: invalid decode source length")
-	} else if len(dst) < n {
+	}
+
+	srcPtr := unsafe.Pointer(&src[0])
+
+	m, n, err := enc.decodeLen(srcPtr, len(src))
+	if errors.Is(err, ErrInvalidBase32Length) || (err == nil && n == 0) {
+		panic("base32: invalid decode source length")
+	} else if err != nil {
+		return err
+	}
+
+	if len(dst) < n {
 		panic("base32: decode destination too short")
 	}
 
-	return decode(unsafe.Pointer(&dst[0]), unsafe.Pointer(&src[0]), len(src))
+	return enc.decodeInput(unsafe.Pointer(&dst[0]), srcPtr, m)
 }
 
 // Decode returns the decoded form of src if src is not empty. If src is
@@ -204,21 +451,27 @@ func UnsafeDecode(dst []byte, src []byte) error {
 // ignore it. If it is sensitive consider clearing the slice of
 // contents. There is no guarantee about the contents of the slice when a
 // non-nil error is returned. It could be partially decoded or contain
-// empty bytes.
-func Decode(src []byte) ([]byte, error) {
+// empty bytes. DecodeSecret clears it for you.
+func (enc *Encoding) Decode(src []byte) ([]byte, error) {
 	n := len(src)
 	if n == 0 {
 		return nil, nil
 	}
 
-	n = decodedLen(n)
-	if n < 0 {
-		return nil, ErrInvalidBase32Length
+	srcPtr := unsafe.Pointer(&src[0])
+
+	m, n, err := enc.decodeLen(srcPtr, n)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return nil, nil
 	}
 
 	dst := make([]byte, n)
 
-	err := decode(unsafe.Pointer(&dst[0]), unsafe.Pointer(&src[0]), len(src))
+	err = enc.decodeInput(unsafe.Pointer(&dst[0]), srcPtr, m)
 	return dst, err
 }
 
@@ -233,21 +486,27 @@ func Decode(src []byte) ([]byte, error) {
 // ignore it. If it is sensitive consider clearing the slice of
 // contents. There is no guarantee about the contents of the slice when a
 // non-nil error is returned. It could be partially decoded or contain
-// empty bytes.
-func DecodeString(src string) ([]byte, error) {
+// empty bytes. DecodeSecretString clears it for you.
+func (enc *Encoding) DecodeString(src string) ([]byte, error) {
 	n := len(src)
 	if n == 0 {
 		return nil, nil
 	}
 
-	n = decodedLen(n)
-	if n < 0 {
-		return nil, ErrInvalidBase32Length
+	srcPtr := unsafe.Pointer(unsafe.StringData(src))
+
+	m, n, err := enc.decodeLen(srcPtr, n)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return nil, nil
 	}
 
 	dst := make([]byte, n)
 
-	err := decode(unsafe.Pointer(&dst[0]), unsafe.Pointer(unsafe.StringData(src)), len(src))
+	err = enc.decodeInput(unsafe.Pointer(&dst[0]), srcPtr, m)
 	return dst, err
 }
 
@@ -262,23 +521,29 @@ func DecodeString(src string) ([]byte, error) {
 // ignore it. If it is sensitive consider clearing the slice of
 // newly appended contents. There is no guarantee about the contents of
 // the appended slice when a non-nil error is returned. It could be
-// partially decoded or contain empty bytes.
-func AppendDecode(dst, src []byte) ([]byte, error) {
+// partially decoded or contain empty bytes. AppendDecodeAtomic clears
+// it for you.
+func (enc *Encoding) AppendDecode(dst, src []byte) ([]byte, error) {
 	n := len(src)
 	if n == 0 {
 		return dst, nil
 	}
 
-	n = decodedLen(n)
-	if n < 0 {
-		return nil, ErrInvalidBase32Length
+	srcPtr := unsafe.Pointer(&src[0])
+
+	m, n, err := enc.decodeLen(srcPtr, n)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return dst, nil
 	}
 	orig := len(dst)
 
 	dst = slices.Grow(dst, n)
 	dst = dst[:orig+n]
 
-	err := decode(unsafe.Pointer(&dst[orig]), unsafe.Pointer(&src[0]), len(src))
+	err = enc.decodeInput(unsafe.Pointer(&dst[orig]), srcPtr, m)
 	return dst, err
 }
 
@@ -293,22 +558,62 @@ func AppendDecode(dst, src []byte) ([]byte, error) {
 // ignore it. If it is sensitive consider clearing the slice of
 // newly appended contents. There is no guarantee about the contents of
 // the appended slice when a non-nil error is returned. It could be
-// partially decoded or contain empty bytes.
-func AppendDecodeString(dst []byte, src string) ([]byte, error) {
+// partially decoded or contain empty bytes. AppendDecodeStringAtomic
+// clears it for you.
+func (enc *Encoding) AppendDecodeString(dst []byte, src string) ([]byte, error) {
 	n := len(src)
 	if n == 0 {
 		return dst, nil
 	}
 
-	n = decodedLen(n)
-	if n < 0 {
-		return nil, ErrInvalidBase32Length
+	srcPtr := unsafe.Pointer(unsafe.StringData(src))
+
+	m, n, err := enc.decodeLen(srcPtr, n)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return dst, nil
 	}
 	orig := len(dst)
 
 	dst = slices.Grow(dst, n)
 	dst = dst[:orig+n]
 
-	err := decode(unsafe.Pointer(&dst[orig]), unsafe.Pointer(unsafe.StringData(src)), len(src))
+	err = enc.decodeInput(unsafe.Pointer(&dst[orig]), srcPtr, m)
 	return dst, err
 }
+
+// DecodeStringInto decodes src into dst, which the decoded form of src
+// must fill exactly, without allocating. It suits fixed-size values
+// such as identifiers held in arrays.
+//
+// Errors are *DecodeError values as returned by DecodeString, along
+// with an InvalidLength error at the end of src if src does not decode
+// to len(dst) bytes. If an error is returned the contents of dst are
+// unspecified.
+func (enc *Encoding) DecodeStringInto(dst []byte, src string) error {
+	return enc.decodeFixed(dst, src)
+}
+
+// decodeFixed decodes src into dst, which it must fill exactly. The
+// length is checked before anything is decoded so dst is never
+// outgrown and nothing is allocated.
+func (enc *Encoding) decodeFixed(dst []byte, src string) error {
+	if len(src) == 0 {
+		return validSize(0, 0, len(dst))
+	}
+
+	srcPtr := unsafe.Pointer(unsafe.StringData(src))
+
+	m, n, err := enc.decodeLen(srcPtr, len(src))
+	if err != nil {
+		return err
+	}
+
+	if err := validSize(len(src), n, len(dst)); err != nil || n == 0 {
+		return err
+	}
+
+	return enc.decodeInput(unsafe.Pointer(&dst[0]), srcPtr, m)
+}
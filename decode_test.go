@@ -1,6 +1,7 @@
 package base32
 
 import (
+	"errors"
 	"iter"
 	"math"
 	"slices"
@@ -242,7 +243,7 @@ func checkDecodeTCR(t *testing.T, cfg tbdd.Assert[decodeTC, decodeTCR]) {
 	if tc.expErr == nil && tc.expErrStr == "" {
 		is.Nil(r.err)
 		is.Equal(tc.expStr, r.str)
-	} else if tc.call.canHaveNilDst() && (len(tc.src) == 0 || r.err == ErrInvalidBase32Length) {
+	} else if tc.call.canHaveNilDst() && (len(tc.src) == 0 || errors.Is(r.err, ErrInvalidBase32Length)) {
 		is.True(r.nilDst)
 	}
 
@@ -418,8 +419,9 @@ func TestDecode(t *testing.T) {
 		{
 			When: "8 bytes where last is invalid",
 			TC: decodeTC{
-				src:    "64S36D1U",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1U",
+				expErr:    ErrInvalidBase32Char,
+				expErrStr: `invalid base32 character "U" at offset 7`,
 			},
 		},
 		{
@@ -432,22 +434,25 @@ func TestDecode(t *testing.T) {
 		{
 			When: "31 bytes where last is invalid",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RVKGEU",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RVKGEU",
+				expErr:    ErrInvalidBase32Char,
+				expErrStr: `invalid base32 character "U" at offset 30`,
 			},
 		},
 		{
 			When: "31 bytes with invalid tail bits",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RVKGE4",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RVKGE4",
+				expErr:    ErrNonCanonicalTail,
+				expErrStr: `non-canonical base32 tail bits in "4" at offset 30`,
 			},
 		},
 		{
 			When: "30 bytes",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RVKGE",
-				expErr: ErrInvalidBase32Length,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RVKGE",
+				expErr:    ErrInvalidBase32Length,
+				expErrStr: `invalid base32 length at offset 30`,
 			},
 		},
 		{
@@ -460,29 +465,33 @@ func TestDecode(t *testing.T) {
 		{
 			When: "29 bytes where last is invalid",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RVKU",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RVKU",
+				expErr:    ErrInvalidBase32Char,
+				expErrStr: `invalid base32 character "U" at offset 28`,
 			},
 		},
 		{
 			When: "29 bytes with invalid tail bits",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RVK1",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RVK1",
+				expErr:    ErrNonCanonicalTail,
+				expErrStr: `non-canonical base32 tail bits in "1" at offset 28`,
 			},
 		},
 		{
 			When: "28 bytes where last is invalid",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RVU",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RVU",
+				expErr:    ErrInvalidBase32Char,
+				expErrStr: `invalid base32 character "U" at offset 27`,
 			},
 		},
 		{
 			When: "28 bytes with invalid tail bits",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RV8",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RV8",
+				expErr:    ErrNonCanonicalTail,
+				expErrStr: `non-canonical base32 tail bits in "8" at offset 27`,
 			},
 		},
 		{
@@ -495,22 +504,25 @@ func TestDecode(t *testing.T) {
 		{
 			When: "27 bytes",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6RV",
-				expErr: ErrInvalidBase32Length,
+				src:       "64S36D1N6RVKGE9G64S36D1N6RV",
+				expErr:    ErrInvalidBase32Length,
+				expErrStr: `invalid base32 length at offset 27`,
 			},
 		},
 		{
 			When: "26 bytes where last is invalid",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6U",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N6U",
+				expErr:    ErrInvalidBase32Char,
+				expErrStr: `invalid base32 character "U" at offset 25`,
 			},
 		},
 		{
 			When: "26 bytes with invalid tail bits",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N62",
-				expErr: ErrInvalidBase32Char,
+				src:       "64S36D1N6RVKGE9G64S36D1N62",
+				expErr:    ErrNonCanonicalTail,
+				expErrStr: `non-canonical base32 tail bits in "2" at offset 25`,
 			},
 		},
 		{
@@ -523,8 +535,9 @@ func TestDecode(t *testing.T) {
 		{
 			When: "25 bytes",
 			TC: decodeTC{
-				src:    "64S36D1N6RVKGE9G64S36D1N6",
-				expErr: ErrInvalidBase32Length,
+				src:       "64S36D1N6RVKGE9G64S36D1N6",
+				expErr:    ErrInvalidBase32Length,
+				expErrStr: `invalid base32 length at offset 25`,
 			},
 		},
 		{
@@ -560,17 +573,19 @@ func TestDecode(t *testing.T) {
 		{
 			When: "append-decode source is invalid length",
 			TC: decodeTC{
-				call:   appendDecCall,
-				src:    "0",
-				expErr: ErrInvalidBase32Length,
+				call:      appendDecCall,
+				src:       "0",
+				expErr:    ErrInvalidBase32Length,
+				expErrStr: `invalid base32 length at offset 1`,
 			},
 		},
 		{
 			When: "append-decode source has an invalid char",
 			TC: decodeTC{
-				call:   appendDecCall,
-				src:    "0U",
-				expErr: ErrInvalidBase32Char,
+				call:      appendDecCall,
+				src:       "0U",
+				expErr:    ErrInvalidBase32Char,
+				expErrStr: `invalid base32 character "U" at offset 1`,
 			},
 		},
 	}
@@ -587,12 +602,62 @@ func TestDecode(t *testing.T) {
 			tc.TC.call = decCall
 		}
 
-		// expand single-error checks to string based error checking as well
-		if err := tc.TC.expErr; err != nil && tc.TC.expErrStr == "" {
-			tc.TC.expErrStr = err.Error()
-		}
-
 		f := tc.NewI(t, i)
 		f(t)
 	}
 }
+
+func TestDecodeStringInto(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	var id [10]byte
+
+	is.Nil(DecodeStringInto(id[:], "64S36D1N6RVKGE9G"))
+	is.Equal("1234567890", string(id[:]))
+
+	var id2 [10]byte
+
+	is.Nil(Crockford.WithSeparators("-").DecodeStringInto(id2[:], "64S3-6D1N-6RVK-GE9G"))
+	is.Equal(id, id2)
+
+	var b [1]byte
+
+	is.Nil(StdEncoding.DecodeStringInto(b[:], "MY======"))
+	is.Equal(byte('f'), b[0])
+
+	// nothing decodes into nothing
+	is.Nil(DecodeStringInto(nil, ""))
+	is.Nil(Crockford.WithSeparators("-").DecodeStringInto(nil, "--"))
+
+	// errors
+	for _, tc := range []struct {
+		enc *Encoding
+		dst []byte
+		src string
+		err error
+	}{
+		{Crockford, id[:], "", &DecodeError{Offset: 0, Kind: InvalidLength}},
+		{Crockford, id[:], "64S36D1N6RVKGE", &DecodeError{Offset: 14, Kind: InvalidLength}},
+		{Crockford, id[:], "64S36D1N6RVKGE9G64", &DecodeError{Offset: 18, Kind: InvalidLength}},
+		{Crockford, id[:], "64S36D1N6RVKGE9", &DecodeError{Offset: 15, Kind: InvalidLength}},
+		{Crockford, id[:], "64S36D1N6RVKGE9U", &DecodeError{Offset: 15, Char: 'U', Kind: InvalidChar}},
+		{Crockford.WithSeparators("-"), nil, "-64-", &DecodeError{Offset: 4, Kind: InvalidLength}},
+		{StdEncoding, b[:], "MY=====", &DecodeError{Offset: 7, Kind: InvalidLength}},
+	} {
+		is.Equal(tc.err, tc.enc.DecodeStringInto(tc.dst, tc.src), tc.src)
+	}
+}
+
+// TestDecodeStringIntoAllocs is not parallel as AllocsPerRun requires it.
+func TestDecodeStringIntoAllocs(t *testing.T) {
+	is := assert.New(t)
+
+	var id [10]byte
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = DecodeStringInto(id[:], "64S36D1N6RVKGE9G")
+	})
+	is.Zero(allocs)
+}
@@ -20,11 +20,11 @@ func Test_encodedLen(t *testing.T) {
 	const outputOK = (inputOK/5)*8 + ((inputOK%5)*8+4)/5
 
 	is.PanicsWithValue("base32: invalid encode source length", func() {
-		encodedLen(inputTooBig)
+		Crockford.encodedLen(inputTooBig)
 	})
 	is.Equal(-1, EncodedLength(inputTooBig))
 
-	is.Equal(outputOK, encodedLen(inputOK))
+	is.Equal(outputOK, Crockford.encodedLen(inputOK))
 	is.Equal(outputOK, EncodedLength(inputOK))
 	is.Equal(0, EncodedLength(0))
 	is.Equal(-1, EncodedLength(-inputOK))
@@ -0,0 +1,200 @@
+package base32
+
+// Encoding is a base32 encoding scheme defined by a 32 symbol alphabet
+// and an optional set of decode-only aliases.
+//
+// An Encoding is immutable once created and is safe for concurrent use.
+type Encoding struct {
+	encodeTab [32]byte
+	decodeTab [256]byte
+	padChar   rune
+	sepTab    *[256]bool
+
+	// lenientTab holds the decodeTab in use before Strict was called so
+	// errors can tell non-canonical symbols from invalid bytes. It is nil
+	// unless the encoding is strict.
+	lenientTab *[256]byte
+
+	// constantTime selects the kernels that do not index tables by
+	// the data being processed, see ConstantTime.
+	constantTime bool
+
+	// lenientTail disables the rejection of non-zero tail bits, see
+	// WithLenientTail.
+	lenientTail bool
+
+	// rightAligned places the unused bits at the front of the encoded
+	// value, see RightAligned.
+	rightAligned bool
+}
+
+const (
+	StdPadding rune = '=' // Standard padding character
+	NoPadding  rune = -1  // No padding
+)
+
+// Crockford is the case insensitive Crockford style encoding which
+// decodes the letters O, I, and L as the symbols 0, 1, and 1.
+//
+// The package level functions are all shorthand for calling the method
+// of the same name on Crockford.
+var Crockford = NewEncoding(crockfordAlphabet, map[byte]byte{
+	'O': '0',
+	'I': '1',
+	'L': '1',
+})
+
+// StdEncoding is the standard base32 encoding, as defined in RFC 4648.
+var StdEncoding = NewEncoding(stdAlphabet, nil).WithPadding(StdPadding)
+
+// HexEncoding is the "Extended Hex Alphabet" defined in RFC 4648.
+// It is typically used in DNS.
+var HexEncoding = NewEncoding(hexAlphabet, nil).WithPadding(StdPadding)
+
+// RawStdEncoding is the standard unpadded base32 encoding, as defined
+// in RFC 4648. This is the same as StdEncoding but omits padding
+// characters.
+var RawStdEncoding = StdEncoding.WithPadding(NoPadding)
+
+// RawHexEncoding is the unpadded "Extended Hex Alphabet" defined in
+// RFC 4648. This is the same as HexEncoding but omits padding
+// characters.
+var RawHexEncoding = HexEncoding.WithPadding(NoPadding)
+
+// NewEncoding returns a new Encoding defined by the given alphabet,
+// which must be a 32 byte string, and aliases, which map additional
+// bytes accepted while decoding to the alphabet symbol they stand for.
+//
+// Letters of the alphabet and of the aliases are decoded case
+// insensitively while encoding always uses the alphabet as given.
+//
+// This function panics if the alphabet is not exactly 32 bytes long,
+// if a symbol or alias is defined more than once (ignoring the case of
+// letters), or if an alias does not map to a symbol of the alphabet.
+//
+// The returned Encoding is not padded, see WithPadding.
+func NewEncoding(alphabet string, aliases map[byte]byte) *Encoding {
+	enc := &Encoding{
+		padChar: NoPadding,
+	}
+
+	enc.encodeTab, enc.decodeTab = newTables(alphabet, aliases)
+
+	return enc
+}
+
+// WithPadding creates a new encoding identical to enc except with a
+// specified padding character, or NoPadding to disable padding.
+//
+// A padded encoding always produces a multiple of 8 bytes and its
+// decoder requires the exact number of trailing padding characters
+// implied by the length of the data that precedes them.
+//
+// This function panics if the padding character is '\r', '\n', not a
+// single byte, or is accepted by the decoder as a symbol, alias, or
+// separator. Right-aligned encodings can not be padded.
+func (enc *Encoding) WithPadding(padding rune) *Encoding {
+	if padding != NoPadding {
+		if enc.rightAligned {
+			panic("base32: right-aligned encoding can not be padded")
+		}
+
+		if padding == '\r' || padding == '\n' || padding < 0 || padding > 0xFF || enc.accepts(byte(padding)) || (enc.sepTab != nil && enc.sepTab[byte(padding)]) {
+			panic("base32: invalid padding")
+		}
+	}
+
+	result := *enc
+	result.padChar = padding
+
+	return &result
+}
+
+// unpadded returns enc if it is not padded, otherwise a copy of enc
+// without padding.
+func (enc *Encoding) unpadded() *Encoding {
+	if enc.padChar == NoPadding {
+		return enc
+	}
+
+	result := *enc
+	result.padChar = NoPadding
+
+	return &result
+}
+
+// WithSeparators creates a new encoding identical to enc except that its
+// decoder skips every byte found in separators, such as the hyphens the
+// Crockford specification allows for readability or whitespace from
+// wrapped text. An empty string disables skipping.
+//
+// Separators are never emitted while encoding and they do not count
+// towards the length of an encoded value, so the length and tail-bit
+// rules apply to the remaining symbols just as they do without them.
+//
+// This function panics if a separator is accepted by the decoder as a
+// symbol or alias, if it is the padding character, or if enc is a
+// constant-time encoding.
+func (enc *Encoding) WithSeparators(separators string) *Encoding {
+	result := *enc
+	result.sepTab = nil
+
+	if separators == "" {
+		return &result
+	}
+
+	if enc.constantTime {
+		panic("base32: constant-time encoding can not have separators")
+	}
+
+	var sepTab [256]bool
+	for i := range len(separators) {
+		c := separators[i]
+
+		if enc.accepts(c) || rune(c) == enc.padChar {
+			panic("base32: invalid separator")
+		}
+
+		sepTab[c] = true
+	}
+
+	result.sepTab = &sepTab
+
+	return &result
+}
+
+// accepts reports whether c is a symbol or alias of enc, including those
+// rejected by a strict encoding.
+func (enc *Encoding) accepts(c byte) bool {
+	if enc.lenientTab != nil {
+		return enc.lenientTab[c] != b32Invalid
+	}
+
+	return enc.decodeTab[c] != b32Invalid
+}
+
+// WithLenientTail creates a new encoding identical to enc except that
+// its decoder ignores the unused low bits of the final symbol rather
+// than failing with a NonCanonicalTail *DecodeError.
+//
+// Rejecting those bits is the default for good reason: they are never
+// set by a correct encoder, so they usually signal truncated or corrupt
+// input. Only use this option to consume data from an encoder known to
+// leave garbage in them. Several distinct inputs then decode to the same
+// value; Normalize produces the single canonical form.
+func (enc *Encoding) WithLenientTail() *Encoding {
+	result := *enc
+	result.lenientTail = true
+
+	return &result
+}
+
+// tailCheck returns the mask to apply to the unused bits of a final
+// symbol before checking that they are zero.
+func (enc *Encoding) tailCheck() byte {
+	if enc.lenientTail {
+		return 0
+	}
+
+	return 0xFF
+}
@@ -0,0 +1,342 @@
+package base32
+
+import (
+	stdbase32 "encoding/base32"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEncoding(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const alphabet = "ybndrfg8ejkmcpqxot1uwisza345h769"
+
+	enc := NewEncoding(alphabet, nil)
+
+	src := []byte("1234567890123456789")
+	for i := range len(src) + 1 {
+		src := src[:i]
+
+		s := enc.EncodeString(string(src))
+		is.Len(s, enc.EncodedLength(i))
+		for _, c := range []byte(s) {
+			is.Contains(alphabet, string(c))
+		}
+
+		b, err := enc.DecodeString(s)
+		is.Nil(err)
+		is.Equal(string(src), string(b))
+	}
+
+	is.Equal("yy", enc.EncodeString("\x00"))
+
+	// letters decode case insensitively
+	b, err := enc.DecodeString("YY")
+	is.Nil(err)
+	is.Equal([]byte{0}, b)
+
+	// other encodings' symbols are rejected
+	_, err = enc.DecodeString("00")
+	is.ErrorIs(err, ErrInvalidBase32Char)
+}
+
+func TestCrockford(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const src = "1234567890123456789"
+
+	is.Equal(Crockford.EncodeString(src), EncodeString(src))
+
+	b, err := Crockford.DecodeString("64s36d1n6rvkge9g64s36d1n6rvkge8")
+	is.Nil(err)
+	is.Equal(src, string(b))
+
+	// aliases
+	b, err = Crockford.DecodeString("oIL0")
+	is.Nil(err)
+	is.Equal([]byte{0x00, 0x42}, b)
+}
+
+func TestRFC4648Encodings(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	vectors := []struct {
+		src, std, hex string
+	}{
+		{"", "", ""},
+		{"f", "MY======", "CO======"},
+		{"fo", "MZXQ====", "CPNG===="},
+		{"foo", "MZXW6===", "CPNMU==="},
+		{"foob", "MZXW6YQ=", "CPNMUOG="},
+		{"fooba", "MZXW6YTB", "CPNMUOJ1"},
+		{"foobar", "MZXW6YTBOI======", "CPNMUOJ1E8======"},
+	}
+
+	for _, v := range vectors {
+		for _, c := range []struct {
+			enc, raw *Encoding
+			exp      string
+		}{
+			{StdEncoding, RawStdEncoding, v.std},
+			{HexEncoding, RawHexEncoding, v.hex},
+		} {
+			is.Equal(c.exp, c.enc.EncodeString(v.src))
+			is.Len(c.exp, c.enc.EncodedLength(len(v.src)))
+
+			b, err := c.enc.DecodeString(c.exp)
+			is.Nil(err)
+			is.Equal(v.src, string(b))
+
+			raw := strings.TrimRight(c.exp, "=")
+			is.Equal(raw, c.raw.EncodeString(v.src))
+
+			b, err = c.raw.DecodeString(raw)
+			is.Nil(err)
+			is.Equal(v.src, string(b))
+
+			dst := make([]byte, len(v.src))
+			if len(c.exp) > 0 {
+				is.Nil(c.enc.UnsafeDecode(dst, []byte(c.exp)))
+				is.Equal(v.src, string(dst))
+			}
+		}
+	}
+
+	// matches the standard library for every tail length
+	src := []byte("1234567890123456789")
+	for i := range len(src) + 1 {
+		src := src[:i]
+
+		is.Equal(stdbase32.StdEncoding.EncodeToString(src), string(StdEncoding.Encode(src)))
+		is.Equal(stdbase32.HexEncoding.EncodeToString(src), string(HexEncoding.AppendEncode(nil, src)))
+		is.Equal(stdbase32.StdEncoding.DecodedLen(StdEncoding.EncodedLength(i)), StdEncoding.DecodedLength(StdEncoding.EncodedLength(i)))
+	}
+}
+
+func TestPadding(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	for _, c := range []struct {
+		src string
+		err error
+	}{
+		{"MY=====", ErrInvalidBase32Length},
+		{"MY======MY", ErrInvalidBase32Length},
+		{"========", ErrInvalidBase32Padding},
+		{"M=======", ErrInvalidBase32Padding},
+		{"MZX=====", ErrInvalidBase32Padding},
+		{"MZXW6Y==", ErrInvalidBase32Padding},
+		{"MZXW6YTBOI=======", ErrInvalidBase32Length},
+		{"MY======MY======", ErrInvalidBase32Char},
+		{"M=Y=====", ErrInvalidBase32Padding},
+		{"MZ=Q====", ErrInvalidBase32Char},
+		{"MZ======", ErrInvalidBase32Char},
+	} {
+		_, err := StdEncoding.DecodeString(c.src)
+		is.ErrorIs(err, c.err, c.src)
+
+		_, err = StdEncoding.AppendDecode(nil, []byte(c.src))
+		is.ErrorIs(err, c.err, c.src)
+	}
+
+	is.Equal(-1, StdEncoding.DecodedLength(7))
+	is.Equal(5, StdEncoding.DecodedLength(8))
+	is.Equal(-1, StdEncoding.DecodedLength(-8))
+
+	is.ErrorIs(StdEncoding.UnsafeDecode(make([]byte, 5), []byte("MZX=====")), ErrInvalidBase32Padding)
+	is.PanicsWithValue("base32: invalid decode source length", func() {
+		StdEncoding.UnsafeDecode(make([]byte, 5), []byte("MY"))
+	})
+	is.PanicsWithValue("base32: decode destination too short", func() {
+		StdEncoding.UnsafeDecode(make([]byte, 1), []byte("MZXQ===="))
+	})
+
+	// custom padding characters
+	enc := Crockford.WithPadding('*')
+	is.Equal("CR******", enc.EncodeString("f"))
+	b, err := enc.DecodeString("cr******")
+	is.Nil(err)
+	is.Equal("f", string(b))
+
+	for _, r := range []rune{'\r', '\n', 0x100, -2, 'A', 'o'} {
+		is.PanicsWithValue("base32: invalid padding", func() {
+			Crockford.WithPadding(r)
+		})
+	}
+}
+
+func TestSeparators(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	enc := Crockford.WithSeparators("- \t\r\n")
+
+	const exp = "1234567890123456789"
+
+	for _, src := range []string{
+		"64S36D1N6RVKGE9G64S36D1N6RVKGE8",
+		"64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GE8",
+		"64S36D1N\r\n6RVKGE9G\r\n64S36D1N\r\n6RVKGE8\r\n",
+		"-6-4-S-3-6-D-1-N-6-R-V-K-G-E-9-G-6-4-S-3-6-D-1-N-6-R-V-K-G-E-8-",
+		" 64S36D1N6RVKGE9G 64S36D1N6RVK\tGE8 ",
+	} {
+		b, err := enc.DecodeString(src)
+		is.Nil(err, src)
+		is.Equal(exp, string(b), src)
+
+		b, err = enc.AppendDecode([]byte("test_"), []byte(src))
+		is.Nil(err, src)
+		is.Equal("test_"+exp, string(b), src)
+
+		dst := make([]byte, len(exp))
+		is.Nil(enc.UnsafeDecode(dst, []byte(src)), src)
+		is.Equal(exp, string(dst), src)
+	}
+
+	for _, c := range []struct {
+		src string
+		err error
+	}{
+		{"64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GE", ErrInvalidBase32Length},
+		{"64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GE4", ErrInvalidBase32Char},
+		{"64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GEU", ErrInvalidBase32Char},
+		{"64S3-6D1U-6RVK-GE8", ErrInvalidBase32Char},
+		{"64S36D1U-6RVKGE8", ErrInvalidBase32Char},
+		{"64S3_6D1", ErrInvalidBase32Char},
+	} {
+		_, err := enc.DecodeString(c.src)
+		is.ErrorIs(err, c.err, c.src)
+	}
+
+	// without separators the same input is rejected
+	_, err := Crockford.DecodeString("64S3-6D1")
+	is.ErrorIs(err, ErrInvalidBase32Char)
+	_, err = enc.WithSeparators("").DecodeString("64S3-6D1")
+	is.ErrorIs(err, ErrInvalidBase32Char)
+
+	// input holding nothing but separators decodes to nothing
+	b, err := enc.DecodeString("- -")
+	is.Nil(err)
+	is.Nil(b)
+	b, err = enc.Decode([]byte("- -"))
+	is.Nil(err)
+	is.Nil(b)
+	b, err = enc.AppendDecode([]byte("test_"), []byte("- -"))
+	is.Nil(err)
+	is.Equal("test_", string(b))
+	b, err = enc.AppendDecodeString([]byte("test_"), "- -")
+	is.Nil(err)
+	is.Equal("test_", string(b))
+	is.PanicsWithValue("base32: invalid decode source length", func() {
+		enc.UnsafeDecode(make([]byte, 5), []byte("- -"))
+	})
+	is.PanicsWithValue("base32: invalid decode source length", func() {
+		enc.UnsafeDecode(make([]byte, 5), []byte("0-"))
+	})
+
+	// padding may be interleaved with separators
+	std := StdEncoding.WithSeparators("\r\n")
+	for _, c := range []struct {
+		src, exp string
+		err      error
+	}{
+		{src: "MZXW6YTB\r\nOI======\r\n", exp: "foobar"},
+		{src: "MZXW6YTB\r\nOI====\r\n==", exp: "foobar"},
+		{src: "MZXW6YTB\r\n", exp: "fooba"},
+		{src: "\r\n", exp: ""},
+		{src: "MZXW6YTB\r\nOI=====\r\n", err: ErrInvalidBase32Length},
+		{src: "MZXW6YTB\r\n========\r\n", err: ErrInvalidBase32Padding},
+		{src: "MZXW6YTB\r\nO\r\nI======", exp: "foobar"},
+		{src: "MZXW6YTB\r\nO=\r\n======", err: ErrInvalidBase32Padding},
+	} {
+		b, err := std.DecodeString(c.src)
+		if c.err != nil {
+			is.ErrorIs(err, c.err, c.src)
+			continue
+		}
+
+		is.Nil(err, c.src)
+		is.Equal(c.exp, string(b), c.src)
+	}
+
+	is.PanicsWithValue("base32: invalid separator", func() {
+		Crockford.WithSeparators("-o")
+	})
+	is.PanicsWithValue("base32: invalid separator", func() {
+		StdEncoding.WithSeparators("=")
+	})
+	is.PanicsWithValue("base32: invalid padding", func() {
+		enc.WithPadding('-')
+	})
+}
+
+func TestLenientTail(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const src = "1234567890123456789"
+
+	for _, enc := range []*Encoding{Crockford, StdEncoding, Crockford.WithSeparators("-")} {
+		encodings := []*Encoding{enc.WithLenientTail()}
+		if enc.sepTab == nil {
+			encodings = append(encodings, enc.WithLenientTail().ConstantTime())
+		}
+
+		for _, lenient := range encodings {
+			for i := range len(src) + 1 {
+				exp := enc.EncodeString(src[:i])
+				is.Equal(exp, lenient.EncodeString(src[:i]))
+
+				syms := strings.TrimRight(exp, "=")
+				if len(syms) == 0 {
+					continue
+				}
+
+				k := len(syms) - 1
+				v := enc.decodeTab[syms[k]]
+				mask := tailMask[len(syms)%8]
+
+				for g := range mask + 1 {
+					in := syms[:k] + string(enc.encodeTab[v|g]) + exp[len(syms):]
+
+					b, err := lenient.DecodeString(in)
+					is.Nil(err, in)
+					is.Equal(src[:i], string(b), in)
+					is.Nil(lenient.ValidString(in), in)
+
+					b, err = io.ReadAll(lenient.NewDecoder(strings.NewReader(in)))
+					is.Nil(err, in)
+					is.Equal(src[:i], string(b), in)
+
+					s, err := lenient.NormalizeString(in)
+					is.Nil(err, in)
+					is.Equal(exp, s, in)
+
+					_, err = enc.DecodeString(in)
+					if g == 0 {
+						is.Nil(err, in)
+					} else {
+						is.ErrorIs(err, ErrNonCanonicalTail, in)
+					}
+				}
+			}
+		}
+	}
+
+	// invalid characters are still rejected
+	_, err := Crockford.WithLenientTail().DecodeString("0U")
+	is.ErrorIs(err, ErrInvalidBase32Char)
+}
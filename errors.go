@@ -0,0 +1,117 @@
+package base32
+
+import "strconv"
+
+// DecodeErrorKind identifies the rule an encoded input violated.
+type DecodeErrorKind uint8
+
+const (
+	// InvalidChar means a byte is not a symbol, alias, or separator of
+	// the encoding or is padding found before the end of the data.
+	InvalidChar DecodeErrorKind = iota + 1
+	// NonCanonicalTail means the unused low bits of the final symbol are
+	// not zero.
+	NonCanonicalTail
+	// InvalidLength means the number of symbols can not be produced by
+	// the encoding.
+	InvalidLength
+	// InvalidPadding means the number of trailing padding characters
+	// does not match the length of the data that precedes them.
+	InvalidPadding
+	// InvalidChecksum means a check symbol does not match the value of
+	// the symbols that precede it.
+	InvalidChecksum
+	// NonCanonicalChar means a strict encoding found an alias or a letter
+	// in the wrong case rather than the exact symbol the encoder emits.
+	NonCanonicalChar
+	// Overflow means the leading symbol of a right-aligned encoding has
+	// bits set that do not fit in the decoded bytes.
+	Overflow
+)
+
+func (k DecodeErrorKind) String() string {
+	switch k {
+	case InvalidChar:
+		return "InvalidChar"
+	case NonCanonicalTail:
+		return "NonCanonicalTail"
+	case InvalidLength:
+		return "InvalidLength"
+	case InvalidPadding:
+		return "InvalidPadding"
+	case InvalidChecksum:
+		return "InvalidChecksum"
+	case NonCanonicalChar:
+		return "NonCanonicalChar"
+	case Overflow:
+		return "Overflow"
+	}
+
+	return "DecodeErrorKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// DecodeError describes why and where decoding failed.
+//
+// It wraps the sentinel error matching its Kind so errors.Is continues
+// to work. NonCanonicalTail and NonCanonicalChar errors also match
+// ErrInvalidBase32Char, the error previously returned for such input.
+type DecodeError struct {
+	// Offset is the offset of the input byte at which decoding failed.
+	// For InvalidLength it is the length of the input.
+	Offset int64
+	// Char is the offending byte for InvalidChar, NonCanonicalTail,
+	// NonCanonicalChar, Overflow, and InvalidChecksum failures. It is
+	// zero otherwise.
+	Char byte
+	Kind DecodeErrorKind
+}
+
+func (e *DecodeError) Error() string {
+	var msg string
+
+	switch e.Kind {
+	case InvalidChar:
+		msg = ErrInvalidBase32Char.Error() + " " + quoteByte(e.Char)
+	case NonCanonicalTail:
+		msg = ErrNonCanonicalTail.Error() + " in " + quoteByte(e.Char)
+	case InvalidChecksum:
+		msg = ErrInvalidBase32Checksum.Error() + " " + quoteByte(e.Char)
+	case NonCanonicalChar:
+		msg = ErrNonCanonicalChar.Error() + " " + quoteByte(e.Char)
+	case Overflow:
+		msg = ErrOverflow.Error() + " in " + quoteByte(e.Char)
+	case InvalidLength:
+		msg = ErrInvalidBase32Length.Error()
+	case InvalidPadding:
+		msg = ErrInvalidBase32Padding.Error()
+	default:
+		msg = "base32 decode error " + e.Kind.String()
+	}
+
+	return msg + " at offset " + strconv.FormatInt(e.Offset, 10)
+}
+
+func (e *DecodeError) Unwrap() []error {
+	switch e.Kind {
+	case InvalidChar:
+		return []error{ErrInvalidBase32Char}
+	case NonCanonicalTail:
+		return []error{ErrNonCanonicalTail, ErrInvalidBase32Char}
+	case InvalidLength:
+		return []error{ErrInvalidBase32Length}
+	case InvalidPadding:
+		return []error{ErrInvalidBase32Padding}
+	case InvalidChecksum:
+		return []error{ErrInvalidBase32Checksum}
+	case NonCanonicalChar:
+		return []error{ErrNonCanonicalChar, ErrInvalidBase32Char}
+	case Overflow:
+		return []error{ErrOverflow}
+	}
+
+	return nil
+}
+
+func quoteByte(c byte) string {
+	return strconv.Quote(string([]byte{c}))
+}
@@ -0,0 +1,137 @@
+package base32
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeErrorKind(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	is.Equal("InvalidChar", InvalidChar.String())
+	is.Equal("NonCanonicalTail", NonCanonicalTail.String())
+	is.Equal("InvalidLength", InvalidLength.String())
+	is.Equal("InvalidPadding", InvalidPadding.String())
+	is.Equal("InvalidChecksum", InvalidChecksum.String())
+	is.Equal("NonCanonicalChar", NonCanonicalChar.String())
+	is.Equal("Overflow", Overflow.String())
+	is.Equal("DecodeErrorKind(0)", DecodeErrorKind(0).String())
+	is.Equal("DecodeErrorKind(99)", DecodeErrorKind(99).String())
+}
+
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	type testCase struct {
+		err    DecodeError
+		msg    string
+		errs   []error
+		notErr []error
+	}
+
+	for _, tc := range []testCase{
+		{
+			err:    DecodeError{Offset: 3, Char: 'U', Kind: InvalidChar},
+			msg:    `invalid base32 character "U" at offset 3`,
+			errs:   []error{ErrInvalidBase32Char},
+			notErr: []error{ErrNonCanonicalTail, ErrInvalidBase32Length},
+		},
+		{
+			err:    DecodeError{Offset: 1, Char: '1', Kind: NonCanonicalTail},
+			msg:    `non-canonical base32 tail bits in "1" at offset 1`,
+			errs:   []error{ErrNonCanonicalTail, ErrInvalidBase32Char},
+			notErr: []error{ErrInvalidBase32Length},
+		},
+		{
+			err:    DecodeError{Offset: 9, Kind: InvalidLength},
+			msg:    `invalid base32 length at offset 9`,
+			errs:   []error{ErrInvalidBase32Length},
+			notErr: []error{ErrInvalidBase32Char},
+		},
+		{
+			err:    DecodeError{Offset: 2, Kind: InvalidPadding},
+			msg:    `invalid base32 padding at offset 2`,
+			errs:   []error{ErrInvalidBase32Padding},
+			notErr: []error{ErrInvalidBase32Char},
+		},
+		{
+			err:    DecodeError{Offset: 4, Char: '\n', Kind: InvalidChecksum},
+			msg:    `invalid base32 checksum "\n" at offset 4`,
+			errs:   []error{ErrInvalidBase32Checksum},
+			notErr: []error{ErrInvalidBase32Char},
+		},
+		{
+			err:    DecodeError{Offset: 6, Char: 'o', Kind: NonCanonicalChar},
+			msg:    `non-canonical base32 character "o" at offset 6`,
+			errs:   []error{ErrNonCanonicalChar, ErrInvalidBase32Char},
+			notErr: []error{ErrNonCanonicalTail},
+		},
+		{
+			err:    DecodeError{Offset: 0, Char: '8', Kind: Overflow},
+			msg:    `base32 leading symbol overflow in "8" at offset 0`,
+			errs:   []error{ErrOverflow},
+			notErr: []error{ErrInvalidBase32Char},
+		},
+		{
+			err:    DecodeError{Offset: 5},
+			msg:    `base32 decode error DecodeErrorKind(0) at offset 5`,
+			notErr: []error{ErrInvalidBase32Char, ErrInvalidBase32Length},
+		},
+	} {
+		err := &tc.err
+
+		is.Equal(tc.msg, err.Error())
+
+		for _, v := range tc.errs {
+			is.ErrorIs(err, v)
+		}
+
+		for _, v := range tc.notErr {
+			is.NotErrorIs(err, v)
+		}
+	}
+}
+
+func TestDecodeErrorOffsets(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	type testCase struct {
+		enc    *Encoding
+		src    string
+		kind   DecodeErrorKind
+		char   byte
+		offset int64
+	}
+
+	for _, tc := range []testCase{
+		{Crockford, "0000000U", InvalidChar, 'U', 7},
+		{Crockford, "00000000000U", InvalidChar, 'U', 11},
+		{Crockford, "01", NonCanonicalTail, '1', 1},
+		{Crockford, "000", InvalidLength, 0, 3},
+		{Crockford.WithSeparators("-"), "0000-0000-000U", InvalidChar, 'U', 13},
+		{Crockford.WithSeparators("-"), "0000-0000-01", NonCanonicalTail, '1', 11},
+		{Crockford.WithSeparators("-"), "00-0", InvalidLength, 0, 4},
+		{StdEncoding, "MY=====", InvalidLength, 0, 7},
+		{StdEncoding, "M=======", InvalidPadding, 0, 1},
+		{StdEncoding.WithSeparators("\n"), "MY==\n===", InvalidLength, 0, 8},
+	} {
+		var de *DecodeError
+
+		_, err := tc.enc.DecodeString(tc.src)
+		if !is.True(errors.As(err, &de), tc.src) {
+			continue
+		}
+
+		is.Equal(tc.kind, de.Kind, tc.src)
+		is.Equal(tc.char, de.Char, tc.src)
+		is.Equal(tc.offset, de.Offset, tc.src)
+	}
+}
@@ -0,0 +1,178 @@
+package base32
+
+import (
+	"slices"
+	"unsafe"
+)
+
+// Normalize returns the canonical Crockford form of src.
+//
+// See Encoding.Normalize for details.
+func Normalize(src []byte) ([]byte, error) {
+	return Crockford.Normalize(src)
+}
+
+// NormalizeString returns the canonical Crockford form of src.
+//
+// See Encoding.Normalize for details.
+func NormalizeString(src string) (string, error) {
+	return Crockford.NormalizeString(src)
+}
+
+// AppendNormalize returns the canonical Crockford form of src appended
+// to dst.
+//
+// See Encoding.AppendNormalize for details.
+func AppendNormalize(dst, src []byte) ([]byte, error) {
+	return Crockford.AppendNormalize(dst, src)
+}
+
+// Normalize returns the canonical form of src, the exact bytes enc
+// would produce when encoding the value src decodes to. If src is empty
+// nil is returned.
+//
+// Every symbol or alias accepted by the decoder, in either case, is
+// replaced by the alphabet symbol it stands for. Separators, if enc has
+// any, are removed and padding is rewritten using the padding
+// character of enc. Use enc.WithSeparators to strip hyphens or
+// whitespace from user input.
+//
+// The input is validated exactly as Decode would validate it, including
+// the length and tail-bit rules, but it is never decoded. Encodings
+// created with WithLenientTail have non-zero tail bits cleared. If an
+// error is returned the returned slice is nil.
+func (enc *Encoding) Normalize(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	dst, err := enc.appendNormalize(nil, unsafe.Pointer(&src[0]), len(src))
+	if err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// NormalizeString returns the canonical form of src. If src is empty ""
+// is returned.
+//
+// See Normalize for details.
+func (enc *Encoding) NormalizeString(src string) (string, error) {
+	if len(src) == 0 {
+		return "", nil
+	}
+
+	dst, err := enc.appendNormalize(nil, unsafe.Pointer(unsafe.StringData(src)), len(src))
+	if err != nil {
+		return "", err
+	}
+
+	return string(dst), nil
+}
+
+// AppendNormalize returns the canonical form of src appended to dst. If
+// src is empty dst is returned as-is.
+//
+// If an error is returned dst is returned with its original length;
+// any bytes already written beyond it are unspecified.
+//
+// See Normalize for details.
+func (enc *Encoding) AppendNormalize(dst, src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return dst, nil
+	}
+
+	return enc.appendNormalize(dst, unsafe.Pointer(&src[0]), len(src))
+}
+
+// appendNormalize appends the canonical form of the n bytes of encoded
+// input at srcPtr to dst.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) appendNormalize(dst []byte, srcPtr unsafe.Pointer, n int) ([]byte, error) {
+	m, size, err := enc.decodeLen(srcPtr, n)
+	if err != nil {
+		return dst, err
+	}
+
+	if size == 0 {
+		return dst, nil
+	}
+
+	// The canonical length only depends on the decoded length.
+	k := enc.encodedLen(size)
+	orig := len(dst)
+
+	dst = slices.Grow(dst, k)
+	dst = dst[:orig+k]
+
+	if err := enc.normalize(dst[orig:], srcPtr, m, enc.headLen(size)); err != nil {
+		return dst[:orig], err
+	}
+
+	return dst, nil
+}
+
+// normalize writes the canonical form of the first n bytes at srcPtr to
+// dst, followed by padding to fill dst if enc is padded.
+//
+// invariants:
+//
+// - the n bytes hold a valid number of symbols, as checked by decodeLen
+//
+// - len(dst) is the canonical length of the input
+//
+// Errors are reported in the order validSymbols reports them.
+func (enc *Encoding) normalize(dst []byte, srcPtr unsafe.Pointer, n, head int) error {
+	sep := enc.sepTab
+
+	var v, first byte
+	var k, firstOff, last int
+	for i := range n {
+		c := *(*byte)(unsafe.Add(srcPtr, i))
+
+		if sep != nil && sep[c] {
+			continue
+		}
+
+		v = enc.decodeTab[c]
+		if v == b32Invalid {
+			return enc.charError(c, i)
+		}
+
+		if k == 0 {
+			first, firstOff = v, i
+		}
+
+		dst[k] = enc.encodeTab[v]
+		k++
+		last = i
+
+		if k == head {
+			if err := enc.tailError(srcPtr, k, first, v, firstOff, last); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := enc.tailError(srcPtr, k, first, v, firstOff, last); err != nil {
+		return err
+	}
+
+	// A lenient encoding accepts non-zero unused bits but the canonical
+	// form always has them cleared.
+	if enc.rightAligned {
+		dst[0] = enc.encodeTab[first&^headMask[k%8]]
+	} else {
+		dst[k-1] = enc.encodeTab[v&^tailMask[k%8]]
+	}
+
+	for i := k; i < len(dst); i++ {
+		dst[i] = byte(enc.padChar)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package base32
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	hyphens := Crockford.WithSeparators("- ")
+
+	for _, tc := range []struct {
+		enc *Encoding
+		src string
+		exp string
+	}{
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGE8", "64S36D1N6RVKGE9G64S36D1N6RVKGE8"},
+		{Crockford, "64s36d1n6rvkge9g64s36d1n6rvkge8", "64S36D1N6RVKGE9G64S36D1N6RVKGE8"},
+		{Crockford, "oOiIlL10", "00111110"},
+		{Crockford, "o0", "00"},
+		{hyphens, "64s3-6d1n 6rvk-ge9g-64s3-6d1n-6rvk-ge8", "64S36D1N6RVKGE9G64S36D1N6RVKGE8"},
+		{hyphens, "-o-o-", "00"},
+		{Crockford.Strict().WithSeparators("-"), "64S3-6D1N", "64S36D1N"},
+		{StdEncoding, "my======", "MY======"},
+		{StdEncoding.WithSeparators("\n"), "mzxw6\nytboi\n======", "MZXW6YTBOI======"},
+		{StdEncoding.WithSeparators("\n").WithPadding('.'), "mzxw6\nytboi\n..\n....", "MZXW6YTBOI......"},
+		{RawHexEncoding, "cpnmuoj1", "CPNMUOJ1"},
+	} {
+		b, err := tc.enc.Normalize([]byte(tc.src))
+		is.Nil(err, tc.src)
+		is.Equal(tc.exp, string(b), tc.src)
+
+		s, err := tc.enc.NormalizeString(tc.src)
+		is.Nil(err, tc.src)
+		is.Equal(tc.exp, s, tc.src)
+
+		b, err = tc.enc.AppendNormalize([]byte("test_"), []byte(tc.src))
+		is.Nil(err, tc.src)
+		is.Equal("test_"+tc.exp, string(b), tc.src)
+
+		is.True(tc.enc.IsCanonicalString(tc.exp), tc.src)
+
+		// normalized input decodes to the same value
+		exp, err := tc.enc.DecodeString(tc.src)
+		is.Nil(err, tc.src)
+		b, err = tc.enc.DecodeString(s)
+		is.Nil(err, tc.src)
+		is.Equal(exp, b, tc.src)
+
+		if tc.enc == Crockford {
+			b, err = Normalize([]byte(tc.src))
+			is.Nil(err, tc.src)
+			is.Equal(tc.exp, string(b), tc.src)
+
+			s, err = NormalizeString(tc.src)
+			is.Nil(err, tc.src)
+			is.Equal(tc.exp, s, tc.src)
+
+			b, err = AppendNormalize([]byte("test_"), []byte(tc.src))
+			is.Nil(err, tc.src)
+			is.Equal("test_"+tc.exp, string(b), tc.src)
+		}
+	}
+
+	// empty input or input holding nothing but separators
+	for _, src := range []string{"", "- -"} {
+		b, err := hyphens.Normalize([]byte(src))
+		is.Nil(err)
+		is.Nil(b)
+
+		s, err := hyphens.NormalizeString(src)
+		is.Nil(err)
+		is.Equal("", s)
+
+		b, err = hyphens.AppendNormalize([]byte("test_"), []byte(src))
+		is.Nil(err)
+		is.Equal("test_", string(b))
+	}
+
+	for _, tc := range []struct {
+		enc    *Encoding
+		src    string
+		kind   DecodeErrorKind
+		char   byte
+		offset int64
+	}{
+		{Crockford, "000", InvalidLength, 0, 3},
+		{Crockford, "0000000U", InvalidChar, 'U', 7},
+		{Crockford, "01", NonCanonicalTail, '1', 1},
+		{Crockford, "0i", NonCanonicalTail, 'i', 1},
+		{hyphens, "0-0-0", InvalidLength, 0, 5},
+		{hyphens, "0000_000", InvalidChar, '_', 4},
+		{hyphens, "0-1-", NonCanonicalTail, '1', 2},
+		{Crockford.Strict(), "0o", NonCanonicalChar, 'o', 1},
+		{StdEncoding, "MY=====", InvalidLength, 0, 7},
+		{StdEncoding, "M=======", InvalidPadding, 0, 1},
+		{StdEncoding, "MZ======", NonCanonicalTail, 'Z', 1},
+	} {
+		var de *DecodeError
+
+		b, err := tc.enc.Normalize([]byte(tc.src))
+		is.Nil(b, tc.src)
+		if !is.True(errors.As(err, &de), tc.src) {
+			continue
+		}
+
+		is.Equal(tc.kind, de.Kind, tc.src)
+		is.Equal(tc.char, de.Char, tc.src)
+		is.Equal(tc.offset, de.Offset, tc.src)
+
+		s, err := tc.enc.NormalizeString(tc.src)
+		is.Equal("", s, tc.src)
+		is.Equal(de, err, tc.src)
+
+		b, err = tc.enc.AppendNormalize([]byte("test_"), []byte(tc.src))
+		is.Equal("test_", string(b), tc.src)
+		is.Equal(de, err, tc.src)
+	}
+}
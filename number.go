@@ -0,0 +1,322 @@
+package base32
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FixedWidth is the number of symbols written by the fixed-width integer
+// functions, enough to hold 64 bits.
+const FixedWidth = 13
+
+// FormatUint returns the Crockford numeral of v.
+//
+// See Encoding.FormatUint for details.
+func FormatUint(v uint64) string {
+	return Crockford.FormatUint(v)
+}
+
+// AppendUint returns the Crockford numeral of v appended to dst.
+//
+// See Encoding.FormatUint for details.
+func AppendUint(dst []byte, v uint64) []byte {
+	return Crockford.AppendUint(dst, v)
+}
+
+// ParseUint returns the value of the Crockford numeral s.
+//
+// See Encoding.ParseUint for details.
+func ParseUint(s string) (uint64, error) {
+	return Crockford.ParseUint(s)
+}
+
+// FormatInt returns the Crockford numeral of v.
+//
+// See Encoding.FormatInt for details.
+func FormatInt(v int64) string {
+	return Crockford.FormatInt(v)
+}
+
+// AppendInt returns the Crockford numeral of v appended to dst.
+//
+// See Encoding.FormatInt for details.
+func AppendInt(dst []byte, v int64) []byte {
+	return Crockford.AppendInt(dst, v)
+}
+
+// ParseInt returns the value of the signed Crockford numeral s.
+//
+// See Encoding.ParseInt for details.
+func ParseInt(s string) (int64, error) {
+	return Crockford.ParseInt(s)
+}
+
+// FormatUintFixed returns the fixed-width Crockford numeral of v.
+//
+// See Encoding.FormatUintFixed for details.
+func FormatUintFixed(v uint64) string {
+	return Crockford.FormatUintFixed(v)
+}
+
+// AppendUintFixed returns the fixed-width Crockford numeral of v appended
+// to dst.
+//
+// See Encoding.FormatUintFixed for details.
+func AppendUintFixed(dst []byte, v uint64) []byte {
+	return Crockford.AppendUintFixed(dst, v)
+}
+
+// ParseUintFixed returns the value of the fixed-width Crockford numeral
+// s.
+//
+// See Encoding.ParseUintFixed for details.
+func ParseUintFixed(s string) (uint64, error) {
+	return Crockford.ParseUintFixed(s)
+}
+
+// FormatIntFixed returns the fixed-width Crockford numeral of v.
+//
+// See Encoding.FormatIntFixed for details.
+func FormatIntFixed(v int64) string {
+	return Crockford.FormatIntFixed(v)
+}
+
+// AppendIntFixed returns the fixed-width Crockford numeral of v appended
+// to dst.
+//
+// See Encoding.FormatIntFixed for details.
+func AppendIntFixed(dst []byte, v int64) []byte {
+	return Crockford.AppendIntFixed(dst, v)
+}
+
+// ParseIntFixed returns the value of the fixed-width Crockford numeral
+// s.
+//
+// See Encoding.ParseIntFixed for details.
+func ParseIntFixed(s string) (int64, error) {
+	return Crockford.ParseIntFixed(s)
+}
+
+// FormatUint returns the numeral of v in base 32 using the symbols of
+// enc as digits, most significant digit first and without leading zero
+// digits. Zero is formatted as the single symbol for zero.
+//
+// Unlike Encode this formats a number rather than bytes: 31 is "Z" with
+// the Crockford alphabet where encoding the byte 31 gives "3W".
+func (enc *Encoding) FormatUint(v uint64) string {
+	var buf [FixedWidth]byte
+
+	return string(enc.AppendUint(buf[:0], v))
+}
+
+// AppendUint returns the numeral of v appended to dst.
+//
+// See FormatUint for details.
+func (enc *Encoding) AppendUint(dst []byte, v uint64) []byte {
+	var buf [FixedWidth]byte
+
+	i := len(buf)
+	for {
+		i--
+		buf[i] = enc.encodeTab[v&31]
+
+		v >>= 5
+		if v == 0 {
+			break
+		}
+	}
+
+	return append(dst, buf[i:]...)
+}
+
+// ParseUint returns the value of the base 32 numeral s.
+//
+// Every symbol, alias, and letter case the decoder of enc accepts is a
+// digit and separators, if enc has any, are skipped. Leading zero digits
+// are allowed.
+//
+// Errors are *strconv.NumError values as returned by strconv.ParseUint:
+// Err is strconv.ErrSyntax if s is empty or holds a byte that is not a
+// digit and strconv.ErrRange, along with math.MaxUint64, if the value
+// does not fit in 64 bits.
+func (enc *Encoding) ParseUint(s string) (uint64, error) {
+	const fn = "ParseUint"
+
+	v, n, err := enc.parseUint(s)
+	switch {
+	case err != nil:
+		return v, numError(fn, s, err)
+	case n == 0:
+		return 0, numError(fn, s, strconv.ErrSyntax)
+	}
+
+	return v, nil
+}
+
+// FormatInt returns the numeral of v, preceded by '-' if v is negative.
+//
+// See FormatUint for details.
+func (enc *Encoding) FormatInt(v int64) string {
+	var buf [FixedWidth + 1]byte
+
+	return string(enc.AppendInt(buf[:0], v))
+}
+
+// AppendInt returns the numeral of v appended to dst.
+//
+// See FormatInt for details.
+func (enc *Encoding) AppendInt(dst []byte, v int64) []byte {
+	u := uint64(v)
+	if v < 0 {
+		dst = append(dst, '-')
+		u = -u
+	}
+
+	return enc.AppendUint(dst, u)
+}
+
+// ParseInt returns the value of the base 32 numeral s, which may be
+// preceded by a '+' or '-' sign.
+//
+// Errors are *strconv.NumError values as returned by strconv.ParseInt;
+// on overflow the result is math.MaxInt64 or math.MinInt64. See
+// ParseUint for details.
+func (enc *Encoding) ParseInt(s string) (int64, error) {
+	const fn = "ParseInt"
+
+	digits, neg := s, false
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		digits, neg = s[1:], s[0] == '-'
+	}
+
+	u, n, err := enc.parseUint(digits)
+	switch {
+	case err == strconv.ErrSyntax || err == nil && n == 0:
+		return 0, numError(fn, s, strconv.ErrSyntax)
+	case neg && (err != nil || u > 1<<63):
+		return math.MinInt64, numError(fn, s, strconv.ErrRange)
+	case !neg && (err != nil || u > math.MaxInt64):
+		return math.MaxInt64, numError(fn, s, strconv.ErrRange)
+	case neg:
+		return -int64(u), nil
+	}
+
+	return int64(u), nil
+}
+
+// FormatUintFixed returns the numeral of v left-padded with zero digits
+// to FixedWidth symbols.
+//
+// As every value has the same length, the numerals of an alphabet in
+// ascending byte order, such as Crockford, sort in the same order as the
+// values they hold.
+func (enc *Encoding) FormatUintFixed(v uint64) string {
+	var buf [FixedWidth]byte
+
+	return string(enc.AppendUintFixed(buf[:0], v))
+}
+
+// AppendUintFixed returns the fixed-width numeral of v appended to dst.
+//
+// See FormatUintFixed for details.
+func (enc *Encoding) AppendUintFixed(dst []byte, v uint64) []byte {
+	var buf [FixedWidth]byte
+
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = enc.encodeTab[v&31]
+		v >>= 5
+	}
+
+	return append(dst, buf[:]...)
+}
+
+// ParseUintFixed returns the value of the fixed-width numeral s, which
+// must hold exactly FixedWidth digits.
+//
+// See ParseUint for details.
+func (enc *Encoding) ParseUintFixed(s string) (uint64, error) {
+	const fn = "ParseUintFixed"
+
+	v, n, err := enc.parseUint(s)
+	switch {
+	case err != nil:
+		return v, numError(fn, s, err)
+	case n != FixedWidth:
+		return 0, numError(fn, s, strconv.ErrSyntax)
+	}
+
+	return v, nil
+}
+
+// FormatIntFixed returns the fixed-width numeral of v with its sign bit
+// flipped, so that negative values sort before positive ones.
+//
+// See FormatUintFixed for details.
+func (enc *Encoding) FormatIntFixed(v int64) string {
+	return enc.FormatUintFixed(uint64(v) ^ 1<<63)
+}
+
+// AppendIntFixed returns the fixed-width numeral of v appended to dst.
+//
+// See FormatIntFixed for details.
+func (enc *Encoding) AppendIntFixed(dst []byte, v int64) []byte {
+	return enc.AppendUintFixed(dst, uint64(v)^1<<63)
+}
+
+// ParseIntFixed returns the value of the fixed-width numeral s as
+// formatted by FormatIntFixed.
+//
+// On overflow the result is math.MaxInt64. See ParseUintFixed for
+// details.
+func (enc *Encoding) ParseIntFixed(s string) (int64, error) {
+	const fn = "ParseIntFixed"
+
+	v, n, err := enc.parseUint(s)
+	switch {
+	case err == strconv.ErrRange:
+		return math.MaxInt64, numError(fn, s, err)
+	case err != nil || n != FixedWidth:
+		return 0, numError(fn, s, strconv.ErrSyntax)
+	}
+
+	return int64(v ^ 1<<63), nil
+}
+
+// parseUint returns the value of the digits of s along with their count.
+//
+// The error is strconv.ErrSyntax if s holds a byte that is not a digit
+// or separator and strconv.ErrRange, along with math.MaxUint64, if the
+// value does not fit in 64 bits, whichever is found first.
+func (enc *Encoding) parseUint(s string) (uint64, int, error) {
+	var v uint64
+	var n int
+
+	for i := range len(s) {
+		c := s[i]
+
+		if enc.sepTab != nil && enc.sepTab[c] {
+			continue
+		}
+
+		d := enc.decodeTab[c]
+		if d == b32Invalid {
+			return 0, n, strconv.ErrSyntax
+		}
+
+		if v>>59 != 0 {
+			return math.MaxUint64, n, strconv.ErrRange
+		}
+
+		v = v<<5 | uint64(d)
+		n++
+	}
+
+	return v, n, nil
+}
+
+// numError returns a *strconv.NumError as the strconv function of the
+// same name would.
+func numError(fn, s string, err error) *strconv.NumError {
+	return &strconv.NumError{Func: fn, Num: strings.Clone(s), Err: err}
+}
@@ -0,0 +1,184 @@
+package base32
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumber(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	// reference: strconv base 32 digits mapped onto the Crockford alphabet
+	digits := "0123456789abcdefghijklmnopqrstuv"
+	toCrockford := func(s string) string {
+		b := []byte(s)
+		for i, c := range b {
+			if j := strings.IndexByte(digits, c); j >= 0 {
+				b[i] = crockfordAlphabet[j]
+			}
+		}
+		return string(b)
+	}
+
+	r := rand.New(rand.NewPCG(7, 8))
+
+	values := []uint64{0, 1, 31, 32, 1023, 1024, math.MaxInt64, 1 << 63, math.MaxUint64}
+	for range 1000 {
+		values = append(values, r.Uint64()>>r.UintN(64))
+	}
+
+	for _, v := range values {
+		s := toCrockford(strconv.FormatUint(v, 32))
+		is.Equal(s, FormatUint(v))
+		is.Equal("n="+s, string(AppendUint([]byte("n="), v)))
+
+		p, err := ParseUint(s)
+		is.Nil(err, s)
+		is.Equal(v, p, s)
+
+		p, err = ParseUint(strings.ToLower(s))
+		is.Nil(err, s)
+		is.Equal(v, p, s)
+
+		p, err = ParseUint("000" + s)
+		is.Nil(err, s)
+		is.Equal(v, p, s)
+
+		fixed := FormatUintFixed(v)
+		is.Len(fixed, FixedWidth)
+		is.Equal(strings.Repeat("0", FixedWidth-len(s))+s, fixed)
+		is.Equal("n="+fixed, string(AppendUintFixed([]byte("n="), v)))
+
+		p, err = ParseUintFixed(fixed)
+		is.Nil(err, fixed)
+		is.Equal(v, p, fixed)
+
+		i := int64(v)
+		s = toCrockford(strconv.FormatInt(i, 32))
+		is.Equal(s, FormatInt(i))
+		is.Equal("n="+s, string(AppendInt([]byte("n="), i)))
+
+		q, err := ParseInt(s)
+		is.Nil(err, s)
+		is.Equal(i, q, s)
+
+		fixed = FormatIntFixed(i)
+		is.Len(fixed, FixedWidth)
+		is.Equal("n="+fixed, string(AppendIntFixed([]byte("n="), i)))
+
+		q, err = ParseIntFixed(fixed)
+		is.Nil(err, fixed)
+		is.Equal(i, q, fixed)
+	}
+
+	// fixed-width numerals sort like their values
+	ints := make([]int64, len(values))
+	for i, v := range values {
+		ints[i] = int64(v)
+	}
+	ints = append(ints, math.MinInt64, -1, 0)
+
+	slices.Sort(values)
+	slices.Sort(ints)
+
+	for i := 1; i < len(values); i++ {
+		is.LessOrEqual(FormatUintFixed(values[i-1]), FormatUintFixed(values[i]))
+		is.LessOrEqual(FormatIntFixed(ints[i-1]), FormatIntFixed(ints[i]))
+	}
+
+	// numbers, not bytes
+	is.Equal("Z", FormatUint(31))
+	is.Equal("3W", EncodeString("\x1F"))
+	is.Equal("-Z", FormatInt(-31))
+	is.Equal("-8000000000000", FormatInt(math.MinInt64))
+	is.Equal("0000000000000", FormatIntFixed(math.MinInt64))
+	is.Equal("7ZZZZZZZZZZZZ", FormatIntFixed(-1))
+	is.Equal("8000000000000", FormatIntFixed(0))
+	is.Equal("FZZZZZZZZZZZZ", FormatUintFixed(math.MaxUint64))
+
+	// aliases and separators
+	v, err := ParseUint("1O")
+	is.Nil(err)
+	is.Equal(uint64(32), v)
+
+	v, err = ParseUint("il")
+	is.Nil(err)
+	is.Equal(uint64(33), v)
+
+	v, err = Crockford.WithSeparators("-").ParseUint("-1-0-")
+	is.Nil(err)
+	is.Equal(uint64(32), v)
+
+	i, err := ParseInt("+Z")
+	is.Nil(err)
+	is.Equal(int64(31), i)
+
+	// errors
+	for _, tc := range []struct {
+		fn  func(string) (any, error)
+		src string
+		v   any
+		err error
+	}{
+		{wrapU(ParseUint), "", uint64(0), strconv.ErrSyntax},
+		{wrapU(ParseUint), "U", uint64(0), strconv.ErrSyntax},
+		{wrapU(ParseUint), "-1", uint64(0), strconv.ErrSyntax},
+		{wrapU(ParseUint), "G000000000000", uint64(math.MaxUint64), strconv.ErrRange},
+		{wrapU(ParseUint), "ZZZZZZZZZZZZZU", uint64(math.MaxUint64), strconv.ErrRange},
+		{wrapU(Crockford.Strict().ParseUint), "z", uint64(0), strconv.ErrSyntax},
+		{wrapU(Crockford.WithSeparators("-").ParseUint), "-", uint64(0), strconv.ErrSyntax},
+		{wrapI(ParseInt), "", int64(0), strconv.ErrSyntax},
+		{wrapI(ParseInt), "-", int64(0), strconv.ErrSyntax},
+		{wrapI(ParseInt), "+", int64(0), strconv.ErrSyntax},
+		{wrapI(ParseInt), "--1", int64(0), strconv.ErrSyntax},
+		{wrapI(ParseInt), "-U", int64(0), strconv.ErrSyntax},
+		{wrapI(ParseInt), "8000000000000", int64(math.MaxInt64), strconv.ErrRange},
+		{wrapI(ParseInt), "-8000000000001", int64(math.MinInt64), strconv.ErrRange},
+		{wrapI(ParseInt), "-G000000000000", int64(math.MinInt64), strconv.ErrRange},
+		{wrapI(ParseInt), "+G000000000000", int64(math.MaxInt64), strconv.ErrRange},
+		{wrapU(ParseUintFixed), "0", uint64(0), strconv.ErrSyntax},
+		{wrapU(ParseUintFixed), "00000000000000", uint64(0), strconv.ErrSyntax},
+		{wrapU(ParseUintFixed), "000000000000U", uint64(0), strconv.ErrSyntax},
+		{wrapU(ParseUintFixed), "G000000000000", uint64(math.MaxUint64), strconv.ErrRange},
+		{wrapI(ParseIntFixed), "0", int64(0), strconv.ErrSyntax},
+		{wrapI(ParseIntFixed), "-000000000000", int64(0), strconv.ErrSyntax},
+		{wrapI(ParseIntFixed), "G000000000000", int64(math.MaxInt64), strconv.ErrRange},
+	} {
+		v, err := tc.fn(tc.src)
+		is.Equal(tc.v, v, tc.src)
+		is.ErrorIs(err, tc.err, tc.src)
+
+		var ne *strconv.NumError
+		if is.ErrorAs(err, &ne, tc.src) {
+			is.Equal(tc.src, ne.Num)
+		}
+	}
+
+	_, err = ParseInt("U")
+	is.Equal(`strconv.ParseInt: parsing "U": invalid syntax`, err.Error())
+
+	_, err = ParseUintFixed("G000000000000")
+	is.Equal(`strconv.ParseUintFixed: parsing "G000000000000": value out of range`, err.Error())
+}
+
+func wrapU(fn func(string) (uint64, error)) func(string) (any, error) {
+	return func(s string) (any, error) {
+		v, err := fn(s)
+		return v, err
+	}
+}
+
+func wrapI(fn func(string) (int64, error)) func(string) (any, error) {
+	return func(s string) (any, error) {
+		v, err := fn(s)
+		return v, err
+	}
+}
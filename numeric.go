@@ -0,0 +1,298 @@
+package base32
+
+import (
+	"math/big"
+	"slices"
+	"strconv"
+	"unsafe"
+)
+
+// FormatBig returns the Crockford numeral of v.
+//
+// See Encoding.FormatBig for details.
+func FormatBig(v *big.Int) string {
+	return Crockford.FormatBig(v)
+}
+
+// AppendBig returns the Crockford numeral of v appended to dst.
+//
+// See Encoding.FormatBig for details.
+func AppendBig(dst []byte, v *big.Int) []byte {
+	return Crockford.AppendBig(dst, v)
+}
+
+// ParseBig returns the value of the signed Crockford numeral s.
+//
+// See Encoding.ParseBig for details.
+func ParseBig(s string) (*big.Int, error) {
+	return Crockford.ParseBig(s)
+}
+
+// EncodeNumeric returns the Crockford numeral of the big-endian number
+// held by src.
+//
+// See Encoding.EncodeNumeric for details.
+func EncodeNumeric(src []byte) []byte {
+	return Crockford.EncodeNumeric(src)
+}
+
+// AppendEncodeNumeric returns the Crockford numeral of the big-endian
+// number held by src appended to dst.
+//
+// See Encoding.EncodeNumeric for details.
+func AppendEncodeNumeric(dst, src []byte) []byte {
+	return Crockford.AppendEncodeNumeric(dst, src)
+}
+
+// DecodeNumeric returns the value of the Crockford numeral src as a
+// width byte big-endian number.
+//
+// See Encoding.DecodeNumeric for details.
+func DecodeNumeric(src []byte, width int) ([]byte, error) {
+	return Crockford.DecodeNumeric(src, width)
+}
+
+// DecodeStringNumeric returns the value of the Crockford numeral src as
+// a width byte big-endian number.
+//
+// See Encoding.DecodeNumeric for details.
+func DecodeStringNumeric(src string, width int) ([]byte, error) {
+	return Crockford.DecodeStringNumeric(src, width)
+}
+
+// FormatBig returns the numeral of v in base 32 using the symbols of enc
+// as digits, preceded by '-' if v is negative. If v is nil "<nil>" is
+// returned, as it is by big.Int.Text.
+//
+// See FormatUint for details.
+func (enc *Encoding) FormatBig(v *big.Int) string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	return string(enc.AppendBig(nil, v))
+}
+
+// AppendBig returns the numeral of v appended to dst.
+//
+// See FormatBig for details.
+func (enc *Encoding) AppendBig(dst []byte, v *big.Int) []byte {
+	if v == nil {
+		return append(dst, "<nil>"...)
+	}
+
+	if v.Sign() < 0 {
+		dst = append(dst, '-')
+	}
+
+	return enc.AppendEncodeNumeric(dst, v.Bytes())
+}
+
+// ParseBig returns the value of the base 32 numeral s, which may be
+// preceded by a '+' or '-' sign. There is no limit to its size.
+//
+// Errors are *strconv.NumError values wrapping strconv.ErrSyntax. See
+// ParseUint for the digits that are accepted.
+func (enc *Encoding) ParseBig(s string) (*big.Int, error) {
+	digits, neg := s, false
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		digits, neg = s[1:], s[0] == '-'
+	}
+
+	// Every digit adds 5 bits, so this many bytes always suffice.
+	buf := make([]byte, (len(digits)*5+7)/8)
+
+	if err := enc.decodeNumeric(buf, digits); err != nil {
+		return nil, numError("ParseBig", s, strconv.ErrSyntax)
+	}
+
+	v := new(big.Int).SetBytes(buf)
+	if neg {
+		v.Neg(v)
+	}
+
+	return v, nil
+}
+
+// EncodeNumeric returns the numeral of the big-endian number held by src
+// in base 32 using the symbols of enc as digits, without leading zero
+// digits.
+//
+// Unlike Encode, which treats src as a stream of bits, leading zero
+// bytes do not change the result and the unused bits are the high bits
+// of the first symbol, as with RightAligned. An empty src, like any src
+// holding only zero bytes, is the number zero and is formatted as the
+// single symbol for zero. The result is never padded.
+func (enc *Encoding) EncodeNumeric(src []byte) []byte {
+	return enc.AppendEncodeNumeric(nil, src)
+}
+
+// AppendEncodeNumeric returns the numeral of the big-endian number held
+// by src appended to dst.
+//
+// See EncodeNumeric for details.
+func (enc *Encoding) AppendEncodeNumeric(dst, src []byte) []byte {
+	for len(src) > 0 && src[0] == 0 {
+		src = src[1:]
+	}
+
+	if len(src) == 0 {
+		return append(dst, enc.encodeTab[0])
+	}
+
+	numeric := enc.unpadded().RightAligned()
+
+	k := encodedLenExpression(len(src))
+	orig := len(dst)
+
+	dst = slices.Grow(dst, k)
+	dst = dst[:orig+k]
+
+	numeric.encodeInput(unsafe.Pointer(&dst[orig]), unsafe.Pointer(&src[0]), len(src))
+
+	// The leading group may start with zero digits as the first byte is
+	// not zero but may be small.
+	z := 0
+	for dst[orig+z] == enc.encodeTab[0] {
+		z++
+	}
+
+	return append(dst[:orig], dst[orig+z:]...)
+}
+
+// DecodeNumeric returns the value of the base 32 numeral src as a width
+// byte big-endian number, left-padded with zero bytes.
+//
+// Digits are accepted as by ParseUint: any symbol, alias, or letter case
+// the decoder of enc accepts, with separators skipped and leading zero
+// digits allowed. Errors are *DecodeError values: the first invalid
+// byte is reported where it is found, a numeral without digits as
+// InvalidLength, and a value that does not fit in width bytes as
+// Overflow at the first non-zero digit. Every byte is checked before an
+// Overflow is reported. The time taken is linear in the length of src.
+//
+// This function panics if width is not positive.
+func (enc *Encoding) DecodeNumeric(src []byte, width int) ([]byte, error) {
+	var s string
+	if len(src) > 0 {
+		s = unsafe.String(&src[0], len(src))
+	}
+
+	return enc.DecodeStringNumeric(s, width)
+}
+
+// DecodeStringNumeric returns the value of the base 32 numeral src as a
+// width byte big-endian number.
+//
+// See DecodeNumeric for details.
+func (enc *Encoding) DecodeStringNumeric(src string, width int) ([]byte, error) {
+	if width <= 0 {
+		panic("base32: invalid numeric width")
+	}
+
+	dst := make([]byte, width)
+
+	if err := enc.decodeNumeric(dst, src); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// decodeNumeric stores the value of the numeral src in dst as a
+// big-endian number, left-padded with zero bytes.
+//
+// The digits that follow any leading zero digits are right-aligned
+// symbols: the leading digits that do not fill a group of 8 form the
+// head, which is summed on its own and checked for overflow, and the
+// whole groups that follow are decoded by the group kernel. The time
+// taken is linear in len(src).
+func (enc *Encoding) decodeNumeric(dst []byte, src string) error {
+	sep := enc.sepTab
+
+	// Validate every digit and count those from the first non-zero one.
+	var n, sig int
+	lead := -1 // offset of the first non-zero digit
+
+	for i := range len(src) {
+		c := src[i]
+
+		if sep != nil && sep[c] {
+			continue
+		}
+
+		d := enc.decodeTab[c]
+		if d == b32Invalid {
+			return enc.charError(c, i)
+		}
+
+		n++
+
+		if lead < 0 && d != 0 {
+			lead = i
+		}
+
+		if lead >= 0 {
+			sig++
+		}
+	}
+
+	if n == 0 {
+		return &DecodeError{Offset: int64(len(src)), Kind: InvalidLength}
+	}
+
+	clear(dst)
+
+	if lead < 0 {
+		return nil
+	}
+
+	h := sig % 8
+	low := sig / 8 * 5 // bytes held by the whole groups
+
+	var head uint64
+	i := lead
+	for k := 0; k < h; i++ {
+		c := src[i]
+
+		if sep != nil && sep[c] {
+			continue
+		}
+
+		head = head<<5 | uint64(enc.decodeTab[c])
+		k++
+	}
+
+	// Without a head the first group starts with a non-zero digit, so
+	// every byte of the groups is needed.
+	size := low
+	for v := head; v != 0; v >>= 8 {
+		size++
+	}
+
+	if size > len(dst) {
+		return &DecodeError{Offset: int64(lead), Char: src[lead], Kind: Overflow}
+	}
+
+	for j := len(dst) - low - 1; head != 0; j-- {
+		dst[j] = byte(head)
+		head >>= 8
+	}
+
+	if low == 0 {
+		return nil
+	}
+
+	// The digits were validated above, so decoding the groups can not
+	// fail.
+	dstPtr := unsafe.Pointer(&dst[len(dst)-low])
+	srcPtr := unsafe.Add(unsafe.Pointer(unsafe.StringData(src)), i)
+
+	if sep != nil {
+		_ = enc.decodeSeparated(dstPtr, srcPtr, len(src)-i)
+	} else {
+		_ = enc.decode(dstPtr, srcPtr, len(src)-i)
+	}
+
+	return nil
+}
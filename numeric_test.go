@@ -0,0 +1,242 @@
+package base32
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumeric(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	// reference: big.Int base 32 digits mapped onto the Crockford alphabet
+	toCrockford := func(v *big.Int) string {
+		b := []byte(v.Text(32))
+		for i, c := range b {
+			if j := strings.IndexByte("0123456789abcdefghijklmnopqrstuv", c); j >= 0 {
+				b[i] = crockfordAlphabet[j]
+			}
+		}
+		return string(b)
+	}
+
+	r := rand.New(rand.NewPCG(9, 10))
+
+	for n := range 40 {
+		src := make([]byte, n)
+		for i := range src {
+			src[i] = byte(r.Uint32())
+		}
+		if n > 0 {
+			src[0] >>= r.UintN(9)
+		}
+
+		v := new(big.Int).SetBytes(src)
+		s := toCrockford(v)
+
+		is.Equal(s, string(EncodeNumeric(src)), src)
+		is.Equal("n="+s, string(AppendEncodeNumeric([]byte("n="), src)), src)
+		is.Equal(s, string(EncodeNumeric(append([]byte{0, 0}, src...))), src)
+		is.Equal(s, FormatBig(v), src)
+		is.Equal("n="+s, string(AppendBig([]byte("n="), v)), src)
+		is.Equal(toCrockford(new(big.Int).Neg(v)), FormatBig(new(big.Int).Neg(v)), src)
+
+		p, err := ParseBig(s)
+		is.Nil(err, s)
+		is.Zero(v.Cmp(p), s)
+
+		p, err = ParseBig("-" + strings.ToLower(s))
+		is.Nil(err, s)
+		is.Zero(new(big.Int).Neg(v).Cmp(p), s)
+
+		if n == 0 {
+			continue
+		}
+
+		// numerals decode to any width that holds them
+		b, err := DecodeStringNumeric(s, n)
+		is.Nil(err, s)
+		is.Equal(src, b, s)
+
+		b, err = Crockford.ConstantTime().DecodeStringNumeric(s, n)
+		is.Nil(err, s)
+		is.Equal(src, b, s)
+
+		b, err = DecodeNumeric([]byte(s), n+3)
+		is.Nil(err, s)
+		is.Equal(append([]byte{0, 0, 0}, src...), b, s)
+
+		// the numeral of an n byte value matches its right-aligned
+		// encoding without leading zero digits
+		is.Equal(strings.TrimLeft(Crockford.RightAligned().EncodeString(string(src)), "0"), strings.TrimLeft(s, "0"))
+	}
+
+	// separators anywhere, including within and between groups
+	hyphens := Crockford.WithSeparators("-")
+	for range 200 {
+		var plain, sep []byte
+		for range 1 + r.IntN(40) {
+			c := crockfordAlphabet[r.IntN(32)]
+			plain = append(plain, c)
+			sep = append(sep, c)
+			for r.IntN(3) == 0 {
+				sep = append(sep, '-')
+			}
+		}
+
+		exp, expErr := DecodeNumeric(plain, 26)
+		b, err := hyphens.DecodeNumeric(sep, 26)
+		is.Nil(expErr, "%s", plain)
+		is.Nil(err, "%s", sep)
+		is.Equal(exp, b, "%s", sep)
+
+		v, err := hyphens.ParseBig("-" + string(sep))
+		is.Nil(err, "%s", sep)
+		is.Zero(new(big.Int).Neg(new(big.Int).SetBytes(exp)).Cmp(v), "%s", sep)
+	}
+
+	// zero
+	is.Equal("0", string(EncodeNumeric(nil)))
+	is.Equal("0", string(EncodeNumeric([]byte{0, 0})))
+	is.Equal("0", FormatBig(new(big.Int)))
+	is.Equal("<nil>", FormatBig(nil))
+	is.Equal("n=<nil>", string(AppendBig([]byte("n="), nil)))
+
+	b, err := DecodeStringNumeric("000", 2)
+	is.Nil(err)
+	is.Equal([]byte{0, 0}, b)
+
+	// numbers, not bits
+	is.Equal("1", string(EncodeNumeric([]byte{1})))
+	is.Equal("04", string(Encode([]byte{1})))
+	is.Equal("7ZZZZZZZZZZZZZZZZZZZZZZZZZ", string(EncodeNumeric(bytes.Repeat([]byte{0xFF}, 16))))
+	is.Equal("1"+strings.Repeat("0", 51), FormatBig(new(big.Int).Lsh(big.NewInt(1), 255)))
+
+	// the value must fit the width
+	b, err = DecodeStringNumeric("7ZZZZZZZZZZZZZZZZZZZZZZZZZ", 16)
+	is.Nil(err)
+	is.Equal(bytes.Repeat([]byte{0xFF}, 16), b)
+
+	b, err = DecodeStringNumeric("0000008ZZZZZZZZZZZZZZZZZZZZZZZZZ", 16)
+	is.Equal(&DecodeError{Offset: 6, Char: '8', Kind: Overflow}, err)
+	is.Nil(b)
+
+	_, err = DecodeStringNumeric("100", 1)
+	is.Equal(&DecodeError{Offset: 0, Char: '1', Kind: Overflow}, err)
+
+	_, err = DecodeStringNumeric("80", 1)
+	is.Equal(&DecodeError{Offset: 0, Char: '8', Kind: Overflow}, err)
+
+	b, err = DecodeStringNumeric("7Z", 1)
+	is.Nil(err)
+	is.Equal([]byte{0xFF}, b)
+
+	// aliases, case, and separators
+	b, err = hyphens.DecodeStringNumeric("-1O-il-", 2)
+	is.Nil(err)
+	is.Equal([]byte{0x80, 0x21}, b)
+
+	v, err := hyphens.ParseBig("-1O-il-")
+	is.Nil(err)
+	is.Equal(int64(-(32*32*32 + 32 + 1)), v.Int64())
+
+	// errors
+	for _, tc := range []struct {
+		enc *Encoding
+		src string
+		err error
+	}{
+		{Crockford, "", &DecodeError{Offset: 0, Kind: InvalidLength}},
+		{hyphens, "--", &DecodeError{Offset: 2, Kind: InvalidLength}},
+		{Crockford, "1U", &DecodeError{Offset: 1, Char: 'U', Kind: InvalidChar}},
+		{Crockford, "1-", &DecodeError{Offset: 1, Char: '-', Kind: InvalidChar}},
+		{hyphens, "-1-U", &DecodeError{Offset: 3, Char: 'U', Kind: InvalidChar}},
+		{Crockford.Strict(), "1z", &DecodeError{Offset: 1, Char: 'z', Kind: NonCanonicalChar}},
+		{Crockford, "ZZZZZZZZZZU", &DecodeError{Offset: 10, Char: 'U', Kind: InvalidChar}},
+	} {
+		b, err := tc.enc.DecodeStringNumeric(tc.src, 4)
+		is.Equal(tc.err, err, tc.src)
+		is.Nil(b, tc.src)
+
+		v, err := tc.enc.ParseBig(tc.src)
+		is.Nil(v, tc.src)
+		is.Equal(&strconv.NumError{Func: "ParseBig", Num: tc.src, Err: strconv.ErrSyntax}, err, tc.src)
+	}
+
+	_, err = DecodeNumeric(nil, 1)
+	is.Equal(&DecodeError{Offset: 0, Kind: InvalidLength}, err)
+
+	for _, s := range []string{"+", "-", "+-1"} {
+		_, err := ParseBig(s)
+		is.ErrorIs(err, strconv.ErrSyntax, s)
+	}
+
+	is.PanicsWithValue("base32: invalid numeric width", func() {
+		_, _ = DecodeNumeric([]byte("1"), 0)
+	})
+}
+
+func TestNumericLarge(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	// a numeral of 1<<18 digits; BenchmarkDecodeNumeric shows the time
+	// per digit does not grow with the length
+	s, src := largeNumeral(1 << 18)
+	v := new(big.Int).SetBytes(src)
+
+	is.Equal(s, FormatBig(v))
+
+	p, err := ParseBig(s)
+	is.Nil(err)
+	is.Zero(v.Cmp(p))
+
+	b, err := DecodeStringNumeric("000"+s, len(src))
+	is.Nil(err)
+	is.Equal(src, b)
+
+	_, err = DecodeStringNumeric(s, len(src)-1)
+	is.Equal(&DecodeError{Offset: 0, Char: s[0], Kind: Overflow}, err)
+}
+
+// largeNumeral returns a numeral of n digits, a multiple of 8, along
+// with the big-endian value it holds.
+func largeNumeral(n int) (string, []byte) {
+	r := rand.New(rand.NewPCG(21, 22))
+
+	src := make([]byte, n*5/8)
+	for i := range src {
+		src[i] = byte(r.Uint32())
+	}
+	src[0] |= 0x80
+
+	return string(EncodeNumeric(src)), src
+}
+
+// BenchmarkDecodeNumeric reports the time per digit for numerals of
+// doubling length. It stays flat as decoding is linear in the length.
+func BenchmarkDecodeNumeric(b *testing.B) {
+	for _, n := range []int{1 << 12, 1 << 13, 1 << 14, 1 << 15, 1 << 16} {
+		s, src := largeNumeral(n)
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+
+			for b.Loop() {
+				if _, err := DecodeStringNumeric(s, len(src)); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/digit")
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package base32
+
+// PrefixRange returns the bounds of the Crockford encoded keys whose
+// decoded bytes start with prefix.
+//
+// See Encoding.PrefixRange for details.
+func PrefixRange(prefix []byte) (lo, hi string) {
+	return Crockford.PrefixRange(prefix)
+}
+
+// DecodePrefixRange returns the bounds of the byte strings whose
+// Crockford encoded form starts with prefix.
+//
+// See Encoding.DecodePrefixRange for details.
+func DecodePrefixRange(prefix string) (lo, hi []byte, err error) {
+	return Crockford.DecodePrefixRange(prefix)
+}
+
+// PrefixRange returns the half-open range [lo, hi) of the encoded forms
+// of the byte strings that start with prefix, for scanning keys stored
+// as encoded text.
+//
+// A byte boundary may fall within a symbol, so the keys holding prefix
+// do not share the encoded form of prefix as a string prefix. Instead lo
+// is the encoded form of prefix itself, the least such key, and hi is
+// the encoded form of the least byte string above every string that
+// starts with prefix. hi is empty if there is no such string, as when
+// prefix is empty or all 0xFF bytes, and the range has no upper bound.
+//
+// The bounds are tight and hold for any key as long as the alphabet of
+// enc is in ascending byte order, as it is for Crockford and
+// HexEncoding, and the keys are encoded by enc aligned to the left and
+// without padding. The bounds are always written that way, even if enc
+// is padded or right-aligned.
+func (enc *Encoding) PrefixRange(prefix []byte) (lo, hi string) {
+	enc = enc.prefixEncoding()
+
+	lo = string(enc.AppendEncode(nil, prefix))
+
+	// The least byte string above every string starting with prefix is
+	// prefix without its trailing 0xFF bytes and its last byte
+	// incremented.
+	n := len(prefix)
+	for n > 0 && prefix[n-1] == 0xFF {
+		n--
+	}
+
+	if n > 0 {
+		next := append([]byte(nil), prefix[:n]...)
+		next[n-1]++
+
+		hi = string(enc.AppendEncode(nil, next))
+	}
+
+	return lo, hi
+}
+
+// DecodePrefixRange returns the half-open range [lo, hi) of the byte
+// strings whose encoded form starts with prefix. It is the inverse of
+// PrefixRange.
+//
+// The symbols of prefix hold 5 bits each, so the final symbol may fix
+// only the high bits of a byte. lo is the least byte string whose
+// encoded form starts with prefix and hi the least byte string above
+// every such string. hi is nil if there is no such string, as when
+// prefix is empty or all of its bits are set, and the range has no upper
+// bound.
+//
+// Symbols are accepted as by DecodeString, with separators skipped, but
+// prefix may be of any length and its unused bits need not be zero. An
+// invalid byte is reported with a *DecodeError.
+func (enc *Encoding) DecodePrefixRange(prefix string) (lo, hi []byte, err error) {
+	var acc uint16 // bits not yet in lo
+	var nb uint    // number of bits in acc
+	var symbols int
+
+	for i := range len(prefix) {
+		c := prefix[i]
+
+		if enc.sepTab != nil && enc.sepTab[c] {
+			continue
+		}
+
+		d := enc.decodeTab[c]
+		if d == b32Invalid {
+			return nil, nil, enc.charError(c, i)
+		}
+
+		symbols++
+
+		acc = acc<<5 | uint16(d)
+		nb += 5
+
+		if nb >= 8 {
+			nb -= 8
+			lo = append(lo, byte(acc>>nb))
+			acc &= 1<<nb - 1
+		}
+	}
+
+	full := len(lo)
+
+	// The final acc bits fix the high bits of the next byte. If they are
+	// zero and the symbols are the complete encoded form of lo, lo is
+	// already the least string.
+	if nb > 0 && (acc != 0 || decodedLen(symbols) < 0) {
+		lo = append(lo, byte(acc<<(8-nb)))
+	}
+
+	if nb > 0 && acc != 1<<nb-1 {
+		hi = append(hi, lo[:full]...)
+		hi = append(hi, byte((acc+1)<<(8-nb)))
+
+		return lo, hi, nil
+	}
+
+	// Every fixed bit of the next byte is set or there is none, so the
+	// bound follows lo[:full] as it does in PrefixRange.
+	n := full
+	for n > 0 && lo[n-1] == 0xFF {
+		n--
+	}
+
+	if n > 0 {
+		hi = append(hi, lo[:n]...)
+		hi[n-1]++
+	}
+
+	return lo, hi, nil
+}
+
+// prefixEncoding returns enc, or a copy of it, that encodes aligned to
+// the left and without padding.
+func (enc *Encoding) prefixEncoding() *Encoding {
+	enc = enc.unpadded()
+	if !enc.rightAligned {
+		return enc
+	}
+
+	raw := *enc
+	raw.rightAligned = false
+
+	return &raw
+}
@@ -0,0 +1,186 @@
+package base32
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixRange(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	r := rand.New(rand.NewPCG(15, 16))
+
+	// bytes around the boundaries of every bit position
+	edges := []byte{0x00, 0x01, 0x07, 0x08, 0x1F, 0x20, 0x3F, 0x40, 0x7F, 0x80, 0xC0, 0xE0, 0xF7, 0xF8, 0xFE, 0xFF}
+
+	keys := [][]byte{{}}
+	for a := range 256 {
+		keys = append(keys, []byte{byte(a)})
+	}
+	for _, a := range edges {
+		for _, b := range edges {
+			keys = append(keys, []byte{a, b})
+			for i := 0; i < len(edges); i += 3 {
+				keys = append(keys, []byte{a, b, edges[i]})
+			}
+		}
+	}
+	for range 300 {
+		k := make([]byte, 3+r.IntN(4))
+		for i := range k {
+			k[i] = edges[r.IntN(len(edges))]
+		}
+		keys = append(keys, k)
+	}
+
+	inRange := func(v, lo, hi string) bool {
+		return lo <= v && (hi == "" || v < hi)
+	}
+
+	for _, enc := range []*Encoding{Crockford, HexEncoding.WithPadding(NoPadding)} {
+		encoded := make([]string, len(keys))
+		for i, k := range keys {
+			encoded[i] = string(enc.AppendEncode(nil, k))
+		}
+
+		// byte prefixes
+		prefixes := append([][]byte(nil), keys[:257]...)
+		for _, a := range edges {
+			for _, b := range edges {
+				prefixes = append(prefixes, []byte{a, b})
+			}
+		}
+
+		for _, p := range prefixes {
+			lo, hi := enc.PrefixRange(p)
+			is.Equal(string(enc.AppendEncode(nil, p)), lo, p)
+
+			for i, k := range keys {
+				if bytes.HasPrefix(k, p) != inRange(encoded[i], lo, hi) {
+					is.Failf("wrong range", "%x %x", p, k)
+				}
+			}
+		}
+
+		// encoded prefixes
+		var syms []string
+		for i := range 32 {
+			syms = append(syms, string(enc.encodeTab[i]))
+		}
+
+		var prefixes2 []string
+		prefixes2 = append(prefixes2, "")
+		prefixes2 = append(prefixes2, syms...)
+		for _, a := range syms {
+			for _, b := range syms {
+				prefixes2 = append(prefixes2, a+b)
+			}
+		}
+		for range 300 {
+			var sb strings.Builder
+			for range 3 + r.IntN(6) {
+				sb.WriteString([]string{syms[0], syms[1], syms[15], syms[16], syms[30], syms[31]}[r.IntN(6)])
+			}
+			prefixes2 = append(prefixes2, sb.String())
+		}
+
+		for _, s := range prefixes2 {
+			lo, hi, err := enc.DecodePrefixRange(s)
+			is.Nil(err, s)
+			is.True(strings.HasPrefix(string(enc.AppendEncode(nil, lo)), s), s)
+
+			for i, k := range keys {
+				want := strings.HasPrefix(encoded[i], s)
+				got := bytes.Compare(lo, k) <= 0 && (hi == nil || bytes.Compare(k, hi) < 0)
+				if want != got {
+					is.Failf("wrong range", "%s %x", s, k)
+				}
+			}
+		}
+	}
+
+	// byte boundaries within a symbol
+	lo, hi := PrefixRange([]byte("a"))
+	is.Equal("C4", lo)
+	is.Equal("C8", hi)
+	is.Equal("C5H0", EncodeString("ab"))
+
+	lo, hi = PrefixRange([]byte{0x12, 0xFF, 0xFF})
+	is.Equal(EncodeString("\x12\xFF\xFF"), lo)
+	is.Equal(EncodeString("\x13"), hi)
+
+	for _, p := range [][]byte{nil, {0xFF}, {0xFF, 0xFF}} {
+		lo, hi = PrefixRange(p)
+		is.Equal(EncodeString(string(p)), lo, p)
+		is.Empty(hi, p)
+	}
+
+	// bounds are never padded or right-aligned
+	for _, enc := range []*Encoding{Crockford.WithPadding(StdPadding), Crockford.RightAligned()} {
+		lo, hi = enc.PrefixRange([]byte("a"))
+		is.Equal("C4", lo)
+		is.Equal("C8", hi)
+	}
+
+	// 10 bits fix the first byte and the top 2 bits of the second
+	b, e, err := DecodePrefixRange("C4")
+	is.Nil(err)
+	is.Equal([]byte("a"), b)
+	is.Equal([]byte{0x61, 0x40}, e)
+
+	// 15 bits fix all of the first byte and 7 of the second
+	b, e, err = DecodePrefixRange("C5H")
+	is.Nil(err)
+	is.Equal([]byte{0x61, 0x62}, b)
+	is.Equal([]byte{0x61, 0x64}, e)
+
+	// set bits beyond the complete encoded form need another byte
+	b, e, err = DecodePrefixRange("C5")
+	is.Nil(err)
+	is.Equal([]byte{0x61, 0x40}, b)
+	is.Equal([]byte{0x61, 0x80}, e)
+
+	b, e, err = DecodePrefixRange("C7")
+	is.Nil(err)
+	is.Equal([]byte{0x61, 0xC0}, b)
+	is.Equal([]byte{0x62}, e)
+
+	b, e, err = DecodePrefixRange("zZ")
+	is.Nil(err)
+	is.Equal([]byte{0xFF, 0xC0}, b)
+	is.Nil(e)
+
+	b, e, err = DecodePrefixRange("")
+	is.Nil(err)
+	is.Empty(b)
+	is.Nil(e)
+
+	// aliases and separators
+	b, e, err = Crockford.WithSeparators("-").DecodePrefixRange("-c-5-h-")
+	is.Nil(err)
+	is.Equal([]byte{0x61, 0x62}, b)
+	is.Equal([]byte{0x61, 0x64}, e)
+
+	b2, e2, err := DecodePrefixRange("O1")
+	is.Nil(err)
+
+	b, e, err = DecodePrefixRange("01")
+	is.Nil(err)
+	is.Equal(b, b2)
+	is.Equal(e, e2)
+
+	// errors
+	b, e, err = DecodePrefixRange("C-")
+	is.Equal(&DecodeError{Offset: 1, Char: '-', Kind: InvalidChar}, err)
+	is.Nil(b)
+	is.Nil(e)
+
+	_, _, err = Crockford.Strict().DecodePrefixRange("cU")
+	is.Equal(&DecodeError{Offset: 0, Char: 'c', Kind: NonCanonicalChar}, err)
+}
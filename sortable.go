@@ -0,0 +1,194 @@
+package base32
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// SortableTimeSize is the length of the encoded form written by
+// AppendSortableTime. Sortable 64 bit values are FixedWidth symbols long.
+const SortableTimeSize = 20
+
+var ErrInvalidSortableTime = errors.New("invalid base32 sortable time")
+
+// AppendSortableInt64 returns the order-preserving Crockford encoded
+// form of v appended to dst.
+//
+// See Encoding.AppendSortableInt64 for details.
+func AppendSortableInt64(dst []byte, v int64) []byte {
+	return Crockford.AppendSortableInt64(dst, v)
+}
+
+// ParseSortableInt64 returns the value of the order-preserving Crockford
+// encoded form s.
+//
+// See Encoding.ParseSortableInt64 for details.
+func ParseSortableInt64(s string) (int64, error) {
+	return Crockford.ParseSortableInt64(s)
+}
+
+// AppendSortableFloat64 returns the order-preserving Crockford encoded
+// form of f appended to dst.
+//
+// See Encoding.AppendSortableFloat64 for details.
+func AppendSortableFloat64(dst []byte, f float64) []byte {
+	return Crockford.AppendSortableFloat64(dst, f)
+}
+
+// ParseSortableFloat64 returns the value of the order-preserving
+// Crockford encoded form s.
+//
+// See Encoding.ParseSortableFloat64 for details.
+func ParseSortableFloat64(s string) (float64, error) {
+	return Crockford.ParseSortableFloat64(s)
+}
+
+// AppendSortableTime returns the order-preserving Crockford encoded form
+// of t appended to dst.
+//
+// See Encoding.AppendSortableTime for details.
+func AppendSortableTime(dst []byte, t time.Time) []byte {
+	return Crockford.AppendSortableTime(dst, t)
+}
+
+// ParseSortableTime returns the time held by the order-preserving
+// Crockford encoded form s.
+//
+// See Encoding.ParseSortableTime for details.
+func ParseSortableTime(s string) (time.Time, error) {
+	return Crockford.ParseSortableTime(s)
+}
+
+// AppendSortableInt64 returns the encoded form of v, with its sign bit
+// flipped, as FixedWidth symbols appended to dst.
+//
+// If the alphabet of enc is in ascending byte order, as it is for
+// Crockford and HexEncoding, the encoded forms sort in the same order as
+// the values they hold. This is the order-preserving counterpart of
+// AppendEncode; see AppendIntFixed for a numeral of the same length.
+//
+// The result is never padded, even if enc is.
+func (enc *Encoding) AppendSortableInt64(dst []byte, v int64) []byte {
+	return enc.appendSortable(dst, uint64(v)^1<<63)
+}
+
+// ParseSortableInt64 returns the value of the encoded form s as written
+// by AppendSortableInt64.
+//
+// Errors are *DecodeError values as returned by DecodeString, along with
+// an InvalidLength error if s is not FixedWidth symbols long.
+func (enc *Encoding) ParseSortableInt64(s string) (int64, error) {
+	v, err := enc.parseSortable(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(v ^ 1<<63), nil
+}
+
+// AppendSortableFloat64 returns the encoded form of the bits of f, with
+// the sign bit flipped for positive values and every bit flipped for
+// negative ones, as FixedWidth symbols appended to dst.
+//
+// The encoded forms sort in numeric order, with -0 just before +0. NaN
+// values sort after +Inf, or before -Inf if their sign bit is set. See
+// AppendSortableInt64 for details.
+func (enc *Encoding) AppendSortableFloat64(dst []byte, f float64) []byte {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits ^= 1 << 63
+	}
+
+	return enc.appendSortable(dst, bits)
+}
+
+// ParseSortableFloat64 returns the value of the encoded form s as
+// written by AppendSortableFloat64. Every bit of the value, including
+// the payload of a NaN, is restored.
+//
+// See ParseSortableInt64 for details.
+func (enc *Encoding) ParseSortableFloat64(s string) (float64, error) {
+	bits, err := enc.parseSortable(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if bits&(1<<63) != 0 {
+		bits ^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+
+	return math.Float64frombits(bits), nil
+}
+
+// AppendSortableTime returns the encoded form of t as SortableTimeSize
+// symbols appended to dst: the 64 bit Unix time in seconds with its sign
+// bit flipped followed by the 32 bit nanosecond within that second.
+//
+// The encoded forms sort in time order. The location and monotonic
+// clock reading of t are not kept. See AppendSortableInt64 for details.
+func (enc *Encoding) AppendSortableTime(dst []byte, t time.Time) []byte {
+	var b [12]byte
+
+	binary.BigEndian.PutUint64(b[:8], uint64(t.Unix())^1<<63)
+	binary.BigEndian.PutUint32(b[8:], uint32(t.Nanosecond()))
+
+	return enc.appendSortableBytes(dst, b[:])
+}
+
+// ParseSortableTime returns the time, in UTC, held by the encoded form s
+// as written by AppendSortableTime.
+//
+// ErrInvalidSortableTime is returned if the nanosecond is not below one
+// second. See ParseSortableInt64 for the other errors, except s must be
+// SortableTimeSize symbols long.
+func (enc *Encoding) ParseSortableTime(s string) (time.Time, error) {
+	var b [12]byte
+
+	if err := enc.parseSortableBytes(b[:], s); err != nil {
+		return time.Time{}, err
+	}
+
+	sec := int64(binary.BigEndian.Uint64(b[:8]) ^ 1<<63)
+
+	nsec := binary.BigEndian.Uint32(b[8:])
+	if nsec >= 1e9 {
+		return time.Time{}, ErrInvalidSortableTime
+	}
+
+	return time.Unix(sec, int64(nsec)).UTC(), nil
+}
+
+func (enc *Encoding) appendSortable(dst []byte, v uint64) []byte {
+	var b [8]byte
+
+	binary.BigEndian.PutUint64(b[:], v)
+
+	return enc.appendSortableBytes(dst, b[:])
+}
+
+func (enc *Encoding) parseSortable(s string) (uint64, error) {
+	var b [8]byte
+
+	if err := enc.parseSortableBytes(b[:], s); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// appendSortableBytes returns the unpadded encoded form of b appended to
+// dst.
+func (enc *Encoding) appendSortableBytes(dst, b []byte) []byte {
+	return enc.unpadded().AppendEncode(dst, b)
+}
+
+// parseSortableBytes decodes s into b, which it must fill exactly.
+func (enc *Encoding) parseSortableBytes(b []byte, s string) error {
+	return enc.unpadded().decodeFixed(b, s)
+}
@@ -0,0 +1,177 @@
+package base32
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortable(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	r := rand.New(rand.NewPCG(11, 12))
+
+	for _, enc := range []*Encoding{Crockford, Crockford.Strict(), HexEncoding, Crockford.RightAligned()} {
+		// integers
+		ints := []int64{math.MinInt64, math.MinInt64 + 1, -1 << 32, -1, 0, 1, 1 << 32, math.MaxInt64 - 1, math.MaxInt64}
+		for range 200 {
+			ints = append(ints, int64(r.Uint64())>>r.UintN(64))
+		}
+		slices.Sort(ints)
+
+		var prev string
+		for i, v := range ints {
+			s := string(enc.AppendSortableInt64(nil, v))
+			is.Len(s, FixedWidth)
+			is.Equal("k="+s, string(enc.AppendSortableInt64([]byte("k="), v)))
+
+			if i > 0 {
+				is.LessOrEqual(prev, s, v)
+			}
+			prev = s
+
+			p, err := enc.ParseSortableInt64(s)
+			is.Nil(err, s)
+			is.Equal(v, p, s)
+		}
+
+		// floats
+		floats := []float64{math.Inf(-1), -math.MaxFloat64, -1, -math.SmallestNonzeroFloat64, math.Copysign(0, -1), 0, math.SmallestNonzeroFloat64, 1, math.MaxFloat64, math.Inf(1)}
+		for range 200 {
+			floats = append(floats, math.Ldexp(r.NormFloat64(), r.IntN(200)-100))
+		}
+		slices.SortFunc(floats, func(a, b float64) int {
+			// order -0 before +0
+			if a == b {
+				return int(math.Float64bits(b)>>63) - int(math.Float64bits(a)>>63)
+			}
+			if a < b {
+				return -1
+			}
+			return 1
+		})
+
+		for i, f := range floats {
+			s := string(enc.AppendSortableFloat64(nil, f))
+			is.Len(s, FixedWidth)
+
+			if i > 0 {
+				is.Less(prev, s, f)
+			}
+			prev = s
+
+			p, err := enc.ParseSortableFloat64(s)
+			is.Nil(err, s)
+			is.Equal(math.Float64bits(f), math.Float64bits(p), s)
+		}
+
+		// NaNs sort outside of the infinities and keep their bits
+		for _, bits := range []uint64{math.Float64bits(math.NaN()), 0x7FF0000000000001, 0xFFF8000000000001} {
+			f := math.Float64frombits(bits)
+			s := string(enc.AppendSortableFloat64(nil, f))
+
+			if bits>>63 == 0 {
+				is.Greater(s, string(enc.AppendSortableFloat64(nil, math.Inf(1))))
+			} else {
+				is.Less(s, string(enc.AppendSortableFloat64(nil, math.Inf(-1))))
+			}
+
+			p, err := enc.ParseSortableFloat64(s)
+			is.Nil(err, s)
+			is.Equal(bits, math.Float64bits(p), s)
+		}
+
+		// times
+		times := []time.Time{
+			time.Unix(-1<<62, 0),
+			time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Unix(-1, 999999999),
+			time.Unix(0, 0),
+			time.Unix(0, 1),
+			time.Date(2026, 10, 16, 12, 0, 0, 123456789, time.UTC),
+			time.Unix(1<<62, 999999999),
+		}
+		for range 200 {
+			times = append(times, time.Unix(int64(r.Uint64())>>(2+r.UintN(62)), r.Int64N(1e9)))
+		}
+		slices.SortFunc(times, time.Time.Compare)
+
+		for i, tm := range times {
+			s := string(enc.AppendSortableTime(nil, tm))
+			is.Len(s, SortableTimeSize)
+			is.Equal("k="+s, string(enc.AppendSortableTime([]byte("k="), tm)))
+
+			if i > 0 {
+				is.LessOrEqual(prev, s, tm)
+			}
+			prev = s
+
+			p, err := enc.ParseSortableTime(s)
+			is.Nil(err, s)
+			is.True(tm.Equal(p), s)
+			is.Equal(time.UTC, p.Location())
+		}
+	}
+
+	// padded encodings never pad sortable keys
+	s := string(StdEncoding.AppendSortableInt64(nil, 1))
+	is.Len(s, FixedWidth)
+
+	v, err := StdEncoding.ParseSortableInt64(s)
+	is.Nil(err)
+	is.Equal(int64(1), v)
+
+	// the package level functions use Crockford
+	s = string(AppendSortableInt64(nil, -1))
+	is.Equal("FZZZZZZZZZZZY", s)
+
+	v, err = ParseSortableInt64(strings.ToLower(s))
+	is.Nil(err)
+	is.Equal(int64(-1), v)
+
+	s = string(AppendSortableFloat64(nil, 1.5))
+	is.Equal(Crockford.EncodeString("\xBF\xF8\x00\x00\x00\x00\x00\x00"), s)
+
+	f, err := ParseSortableFloat64(s)
+	is.Nil(err)
+	is.Equal(1.5, f)
+
+	tm := time.Date(2026, 10, 16, 12, 0, 0, 5, time.FixedZone("X", 3600))
+	s = string(AppendSortableTime(nil, tm))
+
+	p, err := ParseSortableTime(s)
+	is.Nil(err)
+	is.True(tm.Equal(p))
+
+	// errors
+	for _, src := range []string{"", "0", "FZZZZZZZZZZZ", "FZZZZZZZZZZZZZ"} {
+		_, err := ParseSortableInt64(src)
+		is.Equal(&DecodeError{Offset: int64(len(src)), Kind: InvalidLength}, err, src)
+
+		_, err = ParseSortableFloat64(src)
+		is.Equal(&DecodeError{Offset: int64(len(src)), Kind: InvalidLength}, err, src)
+
+		_, err = ParseSortableTime(src)
+		is.Equal(&DecodeError{Offset: int64(len(src)), Kind: InvalidLength}, err, src)
+	}
+
+	_, err = ParseSortableInt64("FZZZZZZZZZZZZ")
+	is.Equal(&DecodeError{Offset: 12, Char: 'Z', Kind: NonCanonicalTail}, err)
+
+	_, err = ParseSortableFloat64("FZZZZZZZZZZZU")
+	is.Equal(&DecodeError{Offset: 12, Char: 'U', Kind: InvalidChar}, err)
+
+	_, err = ParseSortableTime("U0000000000000000000")
+	is.Equal(&DecodeError{Offset: 0, Char: 'U', Kind: InvalidChar}, err)
+
+	// a nanosecond of a whole second or more
+	_, err = ParseSortableTime(EncodeString("\x80\x00\x00\x00\x00\x00\x00\x00\x3B\x9A\xCA\x00"))
+	is.Equal(ErrInvalidSortableTime, err)
+}
@@ -0,0 +1,376 @@
+package base32
+
+import (
+	"errors"
+	"io"
+	"unsafe"
+)
+
+const (
+	// streamGroups is the number of 5 byte groups an encoder or decoder
+	// processes per call into the kernels.
+	streamGroups = 128
+)
+
+var errEncoderClosed = errors.New("base32: write to closed encoder")
+
+type encoder struct {
+	enc  *Encoding
+	w    io.Writer
+	err  error
+	buf  [5]byte // buffered data waiting to be encoded
+	nbuf int     // number of bytes in buf
+	in   [streamGroups * 5]byte
+	out  [streamGroups * 8]byte
+}
+
+// NewEncoder returns a new streaming Crockford encoder.
+//
+// See Encoding.NewEncoder for details.
+func NewEncoder(w io.Writer) io.WriteCloser {
+	return Crockford.NewEncoder(w)
+}
+
+// NewEncoder returns a new streaming encoder. Data written to the
+// returned writer will be encoded using enc and then written to w.
+//
+// Base32 encodings operate in 5 byte groups; when finished writing, the
+// caller must Close the returned encoder to flush any partially written
+// group along with any padding. Close does not close w.
+//
+// The returned encoder also implements io.ReaderFrom so io.Copy can
+// stream data into it without an intermediate buffer.
+//
+// This function panics if enc is right-aligned.
+func (enc *Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	if enc.rightAligned {
+		panic("base32: right-aligned encoding can not be streamed")
+	}
+
+	return &encoder{enc: enc, w: w}
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	var n int
+
+	// Leading fringe.
+	if e.nbuf > 0 {
+		i := copy(e.buf[e.nbuf:], p)
+
+		e.nbuf += i
+		n += i
+		p = p[i:]
+
+		if e.nbuf < len(e.buf) {
+			return n, nil
+		}
+
+		if err := e.flush(e.buf[:]); err != nil {
+			return n, err
+		}
+
+		e.nbuf = 0
+	}
+
+	// Large interior chunks.
+	for len(p) >= len(e.buf) {
+		nn := min(len(p), len(e.in)) / 5 * 5
+
+		if err := e.flush(p[:nn]); err != nil {
+			return n, err
+		}
+
+		n += nn
+		p = p[nn:]
+	}
+
+	// Trailing fringe.
+	e.nbuf = copy(e.buf[:], p)
+	n += e.nbuf
+
+	return n, nil
+}
+
+// ReadFrom reads data from r until EOF or error and encodes it as if it
+// had been passed to Write. The return value n is the number of bytes
+// read. Any error except io.EOF encountered during the read is also
+// returned.
+func (e *encoder) ReadFrom(r io.Reader) (int64, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	var n int64
+
+	for {
+		k := copy(e.in[:], e.buf[:e.nbuf])
+
+		m, rerr := r.Read(e.in[k:])
+		n += int64(m)
+		k += m
+
+		full := k / 5 * 5
+		if full > 0 {
+			if err := e.flush(e.in[:full]); err != nil {
+				e.nbuf = 0
+				return n, err
+			}
+		}
+
+		e.nbuf = copy(e.buf[:], e.in[full:k])
+
+		if rerr != nil {
+			if rerr == io.EOF {
+				return n, nil
+			}
+
+			return n, rerr
+		}
+	}
+}
+
+// Close flushes any pending output from the encoder. It is an error to
+// call Write after calling Close.
+func (e *encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if e.nbuf > 0 {
+		err := e.flush(e.buf[:e.nbuf])
+		e.nbuf = 0
+
+		if err != nil {
+			return err
+		}
+	}
+
+	e.err = errEncoderClosed
+
+	return nil
+}
+
+// flush encodes p and writes the result to the underlying writer.
+//
+// invariants:
+//
+// - 0 < len(p) <= len(e.in)
+func (e *encoder) flush(p []byte) error {
+	n := e.enc.encodedLen(len(p))
+
+	e.enc.encode(unsafe.Pointer(&e.out[0]), unsafe.Pointer(&p[0]), len(p))
+
+	if _, err := e.w.Write(e.out[:n]); err != nil {
+		e.err = err
+		return err
+	}
+
+	return nil
+}
+
+type decoder struct {
+	enc     *Encoding
+	r       io.Reader
+	err     error    // sticky error returned once pending output is drained
+	offset  int64    // stream offset of the next byte read from r
+	pads    int      // number of padding characters seen
+	padOff  int64    // stream offset of the first padding character
+	nsym    int      // number of symbols in sym
+	symOff  [8]int64 // stream offsets of the symbols carried between reads
+	pending []byte   // decoded data not yet returned
+	raw     [streamGroups * 8]byte
+	sym     [streamGroups*8 + 8]byte
+	out     [streamGroups*5 + 5]byte
+}
+
+// NewDecoder returns a new streaming Crockford decoder.
+//
+// See Encoding.NewDecoder for details.
+func NewDecoder(r io.Reader) io.Reader {
+	return Crockford.NewDecoder(r)
+}
+
+// NewDecoder returns a new streaming decoder which reads encoded data
+// from r and decodes it using enc.
+//
+// Complete 8 symbol groups are decoded as they arrive. The length,
+// padding, and tail-bit rules for the final partial group are only
+// applied once r reports io.EOF.
+//
+// Decoding errors are reported as a *DecodeError holding the absolute
+// offset within the stream of the byte that caused the failure. To read
+// line-wrapped input use an encoding that skips line breaks, such as
+// enc.WithSeparators("\r\n").
+//
+// This function panics if enc is right-aligned.
+func (enc *Encoding) NewDecoder(r io.Reader) io.Reader {
+	if enc.rightAligned {
+		panic("base32: right-aligned encoding can not be streamed")
+	}
+
+	return &decoder{enc: enc, r: r}
+}
+
+func (d *decoder) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+
+		if len(p) == 0 {
+			return 0, nil
+		}
+
+		d.fill()
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+
+	return n, nil
+}
+
+// fill reads the next chunk of input from the underlying reader and
+// decodes every complete group of symbols buffered so far, or every
+// buffered symbol once the end of the stream is reached.
+func (d *decoder) fill() {
+	m, rerr := d.r.Read(d.raw[:])
+
+	chunk := d.raw[:m]
+	chunkOff := d.offset
+	carried := d.nsym
+
+	d.offset += int64(m)
+
+	if err := d.scan(chunk, chunkOff); err != nil {
+		d.err = err
+		return
+	}
+
+	n := d.nsym / 8 * 8
+	if rerr == io.EOF {
+		if err := d.finish(); err != nil {
+			d.err = err
+			return
+		}
+
+		n = d.nsym
+	}
+
+	if n > 0 {
+		if err := d.enc.decode(unsafe.Pointer(&d.out[0]), unsafe.Pointer(&d.sym[0]), n); err != nil {
+			de := err.(*DecodeError)
+			de.Offset = d.symbolOffset(int(de.Offset), carried, chunk, chunkOff)
+
+			d.err = err
+			return
+		}
+
+		d.pending = d.out[:decodedLen(n)]
+	}
+
+	// Carry the symbols of any partial group over to the next read.
+	for i := n; i < d.nsym; i++ {
+		d.symOff[i-n] = d.symbolOffset(i, carried, chunk, chunkOff)
+	}
+
+	d.nsym = copy(d.sym[:], d.sym[n:d.nsym])
+
+	if rerr != nil {
+		d.err = rerr
+	}
+}
+
+// scan appends the symbols found in chunk to the symbol buffer while
+// skipping separators and validating the placement of padding.
+func (d *decoder) scan(chunk []byte, chunkOff int64) error {
+	enc := d.enc
+
+	if enc.sepTab == nil && enc.padChar == NoPadding {
+		d.nsym += copy(d.sym[d.nsym:], chunk)
+		return nil
+	}
+
+	for i, c := range chunk {
+		if enc.sepTab != nil && enc.sepTab[c] {
+			continue
+		}
+
+		if rune(c) == enc.padChar {
+			if d.pads == 0 {
+				d.padOff = chunkOff + int64(i)
+			}
+
+			d.pads++
+
+			if d.pads == 8 {
+				return &DecodeError{Offset: d.padOff, Kind: InvalidPadding}
+			}
+
+			continue
+		}
+
+		if d.pads > 0 {
+			return &DecodeError{Offset: chunkOff + int64(i), Char: c, Kind: InvalidChar}
+		}
+
+		d.sym[d.nsym] = c
+		d.nsym++
+	}
+
+	return nil
+}
+
+// finish applies the length and padding rules to the symbols that
+// remain buffered once the end of the stream is reached.
+func (d *decoder) finish() error {
+	rem := d.nsym % 8
+
+	if d.enc.padChar != NoPadding && (rem+d.pads)%8 != 0 {
+		return &DecodeError{Offset: d.offset, Kind: InvalidLength}
+	}
+
+	if (validDecodeRemainder & (uint8(1) << rem)) == 0 {
+		if d.enc.padChar != NoPadding {
+			return &DecodeError{Offset: d.padOff, Kind: InvalidPadding}
+		}
+
+		return &DecodeError{Offset: d.offset, Kind: InvalidLength}
+	}
+
+	return nil
+}
+
+// symbolOffset returns the stream offset of the i-th buffered symbol
+// given the number of symbols carried over from previous reads and the
+// chunk of input just scanned.
+func (d *decoder) symbolOffset(i, carried int, chunk []byte, chunkOff int64) int64 {
+	if i < carried {
+		return d.symOff[i]
+	}
+
+	i -= carried
+
+	enc := d.enc
+	if enc.sepTab == nil && enc.padChar == NoPadding {
+		return chunkOff + int64(i)
+	}
+
+	for j := 0; ; j++ {
+		c := chunk[j]
+
+		if (enc.sepTab != nil && enc.sepTab[c]) || rune(c) == enc.padChar {
+			continue
+		}
+
+		if i == 0 {
+			return chunkOff + int64(j)
+		}
+
+		i--
+	}
+}
@@ -0,0 +1,313 @@
+package base32
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type errWriter struct {
+	n   int
+	err error
+}
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	if w.n <= 0 {
+		return 0, w.err
+	}
+
+	w.n--
+
+	return len(p), nil
+}
+
+func streamTestData(n int) []byte {
+	result := make([]byte, n)
+	for i := range result {
+		result[i] = byte(i*7 + i/251)
+	}
+
+	return result
+}
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	for _, enc := range []*Encoding{Crockford, StdEncoding} {
+		for _, size := range []int{0, 1, 4, 5, 6, 639, 640, 641, 3203} {
+			src := streamTestData(size)
+			exp := string(enc.Encode(src))
+
+			// single write
+			{
+				var buf bytes.Buffer
+
+				w := enc.NewEncoder(&buf)
+				n, err := w.Write(src)
+				is.Nil(err)
+				is.Equal(size, n)
+				is.Nil(w.Close())
+				is.Equal(exp, buf.String())
+			}
+
+			// writes of every chunk size up to 7
+			for chunk := 1; chunk <= 7; chunk++ {
+				var buf bytes.Buffer
+
+				w := enc.NewEncoder(&buf)
+				for p := src; len(p) > 0; {
+					k := min(chunk, len(p))
+
+					n, err := w.Write(p[:k])
+					is.Nil(err)
+					is.Equal(k, n)
+
+					p = p[k:]
+				}
+				is.Nil(w.Close())
+				is.Equal(exp, buf.String(), chunk)
+			}
+
+			// io.Copy uses ReadFrom
+			for _, r := range []io.Reader{
+				bytes.NewReader(src),
+				iotest.OneByteReader(bytes.NewReader(src)),
+				iotest.HalfReader(bytes.NewReader(src)),
+				iotest.DataErrReader(bytes.NewReader(src)),
+			} {
+				var buf bytes.Buffer
+
+				w := enc.NewEncoder(&buf)
+				is.Implements((*io.ReaderFrom)(nil), w)
+
+				n, err := io.Copy(w, r)
+				is.Nil(err)
+				is.Equal(int64(size), n)
+				is.Nil(w.Close())
+				is.Equal(exp, buf.String())
+			}
+		}
+	}
+
+	// package level constructor uses Crockford
+	{
+		var buf bytes.Buffer
+
+		w := NewEncoder(&buf)
+		_, err := w.Write([]byte("1234567890123456789"))
+		is.Nil(err)
+		is.Nil(w.Close())
+		is.Equal("64S36D1N6RVKGE9G64S36D1N6RVKGE8", buf.String())
+
+		_, err = w.Write([]byte("1"))
+		is.ErrorIs(err, errEncoderClosed)
+		is.ErrorIs(w.Close(), errEncoderClosed)
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	errTest := errors.New("test error")
+
+	// write errors are sticky
+	for _, c := range []struct {
+		writes   int
+		src      []string
+		expN     []int
+		writeErr bool
+	}{
+		{0, []string{"123"}, []int{3}, false},
+		{0, []string{"12345"}, []int{0}, true},
+		{0, []string{"123", "45"}, []int{3, 2}, false},
+		{1, []string{"1234567890", "1"}, []int{10, 1}, false},
+		{1, []string{"12345", "67890"}, []int{5, 0}, true},
+	} {
+		w := Crockford.NewEncoder(&errWriter{c.writes, errTest})
+
+		var err error
+		for i, s := range c.src {
+			var n int
+
+			n, err = w.Write([]byte(s))
+			is.Equal(c.expN[i], n)
+		}
+
+		if c.writeErr {
+			is.ErrorIs(err, errTest)
+		}
+
+		is.ErrorIs(w.Close(), errTest)
+
+		_, err = w.Write([]byte("1"))
+		is.ErrorIs(err, errTest)
+	}
+
+	// ReadFrom reports read and write errors
+	{
+		w := Crockford.NewEncoder(io.Discard).(io.ReaderFrom)
+
+		n, err := w.ReadFrom(iotest.TimeoutReader(bytes.NewReader(streamTestData(1000))))
+		is.ErrorIs(err, iotest.ErrTimeout)
+		is.Equal(int64(640), n)
+	}
+
+	{
+		w := Crockford.NewEncoder(&errWriter{0, errTest})
+
+		n, err := w.(io.ReaderFrom).ReadFrom(bytes.NewReader(streamTestData(1000)))
+		is.ErrorIs(err, errTest)
+		is.Equal(int64(640), n)
+
+		n, err = w.(io.ReaderFrom).ReadFrom(bytes.NewReader(streamTestData(1000)))
+		is.ErrorIs(err, errTest)
+		is.Equal(int64(0), n)
+	}
+}
+
+func wrapLines(s string, width int) string {
+	var b strings.Builder
+
+	for len(s) > width {
+		b.WriteString(s[:width])
+		b.WriteString("\r\n")
+		s = s[width:]
+	}
+	b.WriteString(s)
+
+	return b.String()
+}
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	readers := map[string]func(io.Reader) io.Reader{
+		"plain":    func(r io.Reader) io.Reader { return r },
+		"one-byte": iotest.OneByteReader,
+		"half":     iotest.HalfReader,
+		"data-err": iotest.DataErrReader,
+	}
+
+	for _, enc := range []*Encoding{Crockford, StdEncoding} {
+		wrapped := enc.WithSeparators("\r\n")
+
+		for _, size := range []int{0, 1, 4, 5, 6, 639, 640, 641, 3203} {
+			exp := streamTestData(size)
+			src := enc.EncodeString(string(exp))
+
+			for name, wrap := range readers {
+				b, err := io.ReadAll(enc.NewDecoder(wrap(strings.NewReader(src))))
+				is.Nil(err, name)
+				is.Equal(exp, b, name)
+				if size == 0 {
+					is.Empty(b)
+				}
+
+				b, err = io.ReadAll(wrapped.NewDecoder(wrap(strings.NewReader(wrapLines(src, 76)))))
+				is.Nil(err, name)
+				is.Equal(exp, b, name)
+			}
+		}
+	}
+
+	// package level constructor uses Crockford
+	b, err := io.ReadAll(NewDecoder(strings.NewReader("64S36D1N6RVKGE9G64S36D1N6RVKGE8")))
+	is.Nil(err)
+	is.Equal("1234567890123456789", string(b))
+
+	// a zero length read does not consume input
+	r := NewDecoder(strings.NewReader("64S36D1N"))
+	n, err := r.Read(nil)
+	is.Nil(err)
+	is.Equal(0, n)
+}
+
+func TestDecoderErrors(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	// a long valid prefix pushes failures past the first read
+	prefix := EncodeString(string(streamTestData(1000)))
+
+	for _, c := range []struct {
+		enc    *Encoding
+		src    string
+		kind   DecodeErrorKind
+		char   byte
+		offset int64
+	}{
+		{Crockford, "64S36D1U", InvalidChar, 'U', 7},
+		{Crockford, "U4S36D1N", InvalidChar, 'U', 0},
+		{Crockford, prefix + "64S36D1N6RVKGEU", InvalidChar, 'U', int64(len(prefix)) + 14},
+		{Crockford, prefix + "64S36D1N6RVKGE4", NonCanonicalTail, '4', int64(len(prefix)) + 14},
+		{Crockford, prefix + "64S36D1N6RVKGE", InvalidLength, 0, int64(len(prefix)) + 14},
+		{Crockford, "6", InvalidLength, 0, 1},
+		{Crockford.WithSeparators("\n"), "64S3\n6D1U", InvalidChar, 'U', 8},
+		{Crockford.WithSeparators("\n"), "64S36D\n1N6R\nVKGE4\n", NonCanonicalTail, '4', 16},
+		{StdEncoding, "MY======", 0, 0, 0},
+		{StdEncoding, "MY=====", InvalidLength, 0, 7},
+		{StdEncoding, "MY=======", InvalidLength, 0, 9},
+		{StdEncoding, "MZX=====", InvalidPadding, 0, 3},
+		{StdEncoding, "========", InvalidPadding, 0, 0},
+		{StdEncoding, "MY======MY======", InvalidChar, 'M', 8},
+		{StdEncoding, "MZ======", NonCanonicalTail, 'Z', 1},
+		{StdEncoding.WithSeparators("\n"), "MZXW6YTB\nOI====\n==\n", 0, 0, 0},
+		{StdEncoding.WithSeparators("\n"), "MZXW6YTB\nOI==\n===\n", InvalidLength, 0, 18},
+		{StdEncoding.WithSeparators("\n"), "MZXW6YTB\nOI\n====\n==U", InvalidChar, 'U', 19},
+	} {
+		for _, wrap := range []func(io.Reader) io.Reader{
+			func(r io.Reader) io.Reader { return r },
+			iotest.OneByteReader,
+		} {
+			_, err := io.ReadAll(c.enc.NewDecoder(wrap(strings.NewReader(c.src))))
+			if c.kind == 0 {
+				is.Nil(err, c.src)
+				continue
+			}
+
+			var de *DecodeError
+			if is.ErrorAs(err, &de, c.src) {
+				is.Equal(c.kind, de.Kind, c.src)
+				is.Equal(c.char, de.Char, c.src)
+				is.Equal(c.offset, de.Offset, c.src)
+			}
+		}
+	}
+
+	// padding errors match the batch decoder and validator
+	for _, src := range []string{"========", "MZX=====", "MZXW6Y==", "MY=====", "MZ======"} {
+		_, want := StdEncoding.DecodeString(src)
+		is.NotNil(want, src)
+		is.Equal(want, StdEncoding.ValidString(src), src)
+
+		for _, wrap := range []func(io.Reader) io.Reader{
+			func(r io.Reader) io.Reader { return r },
+			iotest.OneByteReader,
+		} {
+			_, err := io.ReadAll(StdEncoding.NewDecoder(wrap(strings.NewReader(src))))
+			is.Equal(want, err, src)
+		}
+	}
+
+	// reader errors are returned after the data decoded before them
+	r := Crockford.NewDecoder(io.MultiReader(strings.NewReader("64S36D1N6R"), iotest.ErrReader(iotest.ErrTimeout)))
+	b, err := io.ReadAll(r)
+	is.ErrorIs(err, iotest.ErrTimeout)
+	is.Equal("12345", string(b))
+
+	n, err := r.Read(make([]byte, 1))
+	is.ErrorIs(err, iotest.ErrTimeout)
+	is.Equal(0, n)
+}
@@ -0,0 +1,126 @@
+package base32
+
+import "unsafe"
+
+// Strict creates a new encoding identical to enc except that its
+// decoder only accepts the exact symbols its encoder emits. Aliases and
+// letters in the other case are rejected with a NonCanonicalChar
+// *DecodeError.
+//
+// Combined with the tail-bit rules this makes decoding injective: every
+// decoded value has exactly one accepted encoded form, which matters
+// when encoded values are signed, hashed, or used as keys. Separators,
+// if any, are still skipped; use an encoding without separators where
+// the raw input must be canonical too, or see IsCanonical.
+func (enc *Encoding) Strict() *Encoding {
+	result := *enc
+
+	if result.lenientTab == nil {
+		lenientTab := enc.decodeTab
+		result.lenientTab = &lenientTab
+	}
+
+	for i := range result.decodeTab {
+		result.decodeTab[i] = b32Invalid
+	}
+
+	for i, c := range enc.encodeTab {
+		result.decodeTab[c] = byte(i)
+	}
+
+	return &result
+}
+
+// IsCanonical reports whether src is exactly the Crockford encoded form
+// of some value.
+//
+// See Encoding.IsCanonical for details.
+func IsCanonical(src []byte) bool {
+	return Crockford.IsCanonical(src)
+}
+
+// IsCanonicalString reports whether src is exactly the Crockford encoded
+// form of some value.
+//
+// See Encoding.IsCanonical for details.
+func IsCanonicalString(src string) bool {
+	return Crockford.IsCanonicalString(src)
+}
+
+// IsCanonical reports whether src is exactly what enc would produce when
+// encoding some value: every symbol is taken from the alphabet as given,
+// there are no separators, the padding (if any) is exact, and the unused
+// bits are zero. An empty src is canonical.
+//
+// It does not allocate and it accepts the same inputs regardless of
+// whether enc is strict.
+func (enc *Encoding) IsCanonical(src []byte) bool {
+	if len(src) == 0 {
+		return true
+	}
+
+	return enc.isCanonical(unsafe.Pointer(&src[0]), len(src))
+}
+
+// IsCanonicalString reports whether src is exactly what enc would
+// produce when encoding some value.
+//
+// See IsCanonical for details.
+func (enc *Encoding) IsCanonicalString(src string) bool {
+	if len(src) == 0 {
+		return true
+	}
+
+	return enc.isCanonical(unsafe.Pointer(unsafe.StringData(src)), len(src))
+}
+
+// isCanonical reports whether the n bytes at srcPtr are the canonical
+// encoded form of some value.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) isCanonical(srcPtr unsafe.Pointer, n int) bool {
+	if enc.padChar != NoPadding {
+		if n%8 != 0 {
+			return false
+		}
+
+		pad := byte(enc.padChar)
+
+		pads := 0
+		for pads < 7 && *(*byte)(unsafe.Add(srcPtr, n-1-pads)) == pad {
+			pads++
+		}
+
+		n -= pads
+	}
+
+	if decodedLen(n) < 0 {
+		return false
+	}
+
+	var v, first byte
+	for i := range n {
+		c := *(*byte)(unsafe.Add(srcPtr, i))
+
+		v = enc.decodeTab[c]
+		if v == b32Invalid || enc.encodeTab[v] != c {
+			return false
+		}
+
+		if i == 0 {
+			first = v
+		}
+	}
+
+	if enc.rightAligned {
+		return first&headMask[n%8] == 0
+	}
+
+	return v&tailMask[n%8] == 0
+}
+
+// tailMask holds, for each valid remainder of symbols in the final
+// group, the bits of the last symbol that do not hold data.
+var tailMask = [8]byte{2: 0x03, 4: 0x0F, 5: 0x01, 7: 0x07}
@@ -0,0 +1,130 @@
+package base32
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrict(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	strict := Crockford.Strict()
+
+	// encoding is unchanged and the original encoding stays lenient
+	is.Equal(Crockford.encodeTab, strict.encodeTab)
+	is.Equal("64S36D1N6RVKGE9G64S36D1N6RVKGE8", strict.EncodeString("1234567890123456789"))
+
+	b, err := Crockford.DecodeString("64s36d1n")
+	is.Nil(err)
+	is.Equal("12345", string(b))
+
+	b, err = strict.DecodeString("64S36D1N6RVKGE9G64S36D1N6RVKGE8")
+	is.Nil(err)
+	is.Equal("1234567890123456789", string(b))
+
+	for _, tc := range []struct {
+		enc    *Encoding
+		src    string
+		kind   DecodeErrorKind
+		char   byte
+		offset int64
+	}{
+		{strict, "64s36D1N", NonCanonicalChar, 's', 2},
+		{strict, "64S36D1N6RVKGE9g", NonCanonicalChar, 'g', 15},
+		{strict, "6RVKGE8o", NonCanonicalChar, 'o', 7},
+		{strict, "O0", NonCanonicalChar, 'O', 0},
+		{strict, "1L", NonCanonicalChar, 'L', 1},
+		{strict, "1i", NonCanonicalChar, 'i', 1},
+		{strict, "1U", InvalidChar, 'U', 1},
+		{strict, "01", NonCanonicalTail, '1', 1},
+		{strict.WithSeparators("-"), "64S3-6D1n", NonCanonicalChar, 'n', 8},
+		{strict.WithSeparators("-"), "64S36D1N-6r", NonCanonicalChar, 'r', 10},
+		{StdEncoding.Strict(), "my======", NonCanonicalChar, 'm', 0},
+		{HexEncoding.Strict().Strict(), "Co======", NonCanonicalChar, 'o', 1},
+	} {
+		var de *DecodeError
+
+		_, err := tc.enc.DecodeString(tc.src)
+		if !is.True(errors.As(err, &de), tc.src) {
+			continue
+		}
+
+		is.Equal(tc.kind, de.Kind, tc.src)
+		is.Equal(tc.char, de.Char, tc.src)
+		is.Equal(tc.offset, de.Offset, tc.src)
+
+		if tc.kind == NonCanonicalChar {
+			is.ErrorIs(err, ErrNonCanonicalChar, tc.src)
+			is.ErrorIs(err, ErrInvalidBase32Char, tc.src)
+		}
+	}
+
+	// streaming decoders of a strict encoding are strict too
+	_, err = io.ReadAll(strict.NewDecoder(strings.NewReader("64S36D1N6RVKGE9G64S36d1N")))
+	is.ErrorIs(err, ErrNonCanonicalChar)
+
+	// bytes accepted by the lenient decoder still can't be used as
+	// separators or padding
+	is.PanicsWithValue("base32: invalid separator", func() {
+		strict.WithSeparators("o")
+	})
+	is.PanicsWithValue("base32: invalid padding", func() {
+		strict.WithPadding('a')
+	})
+}
+
+func TestIsCanonical(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const src = "1234567890123456789"
+
+	for i := range len(src) + 1 {
+		for _, enc := range []*Encoding{Crockford, Crockford.Strict(), StdEncoding, RawHexEncoding} {
+			s := enc.EncodeString(src[:i])
+
+			is.True(enc.IsCanonicalString(s), s)
+			is.True(enc.IsCanonical([]byte(s)), s)
+		}
+
+		s := EncodeString(src[:i])
+		is.True(IsCanonicalString(s), s)
+		is.True(IsCanonical([]byte(s)), s)
+	}
+
+	for _, tc := range []struct {
+		enc *Encoding
+		src string
+	}{
+		{Crockford, "64s36D1N"},
+		{Crockford, "O0"},
+		{Crockford, "01"},
+		{Crockford, "000"},
+		{Crockford, "0U"},
+		{Crockford, "64S3-6D1N"},
+		{Crockford.WithSeparators("-"), "64S3-6D1N"},
+		{StdEncoding, "MY"},
+		{StdEncoding, "MY====="},
+		{StdEncoding, "M======="},
+		{StdEncoding, "========"},
+		{StdEncoding, "MZ======"},
+		{StdEncoding, "MY==\n===="},
+		{StdEncoding, "my======"},
+		{StdEncoding, "M=Y====="},
+	} {
+		is.False(tc.enc.IsCanonicalString(tc.src), tc.src)
+		is.False(tc.enc.IsCanonical([]byte(tc.src)), tc.src)
+
+		if tc.enc == Crockford {
+			is.False(IsCanonicalString(tc.src), tc.src)
+			is.False(IsCanonical([]byte(tc.src)), tc.src)
+		}
+	}
+}
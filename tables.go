@@ -6,45 +6,78 @@ package base32
 
 const b32Invalid = 0xFF
 
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	stdAlphabet       = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	hexAlphabet       = "0123456789ABCDEFGHIJKLMNOPQRSTUV"
+	b32UpToLow        = ('a' - 'A')
+)
+
 //
-// encode and decode tables are using Crockford style case insensitive grammars
+// encode and decode tables default to using case insensitive grammars
 //
 
-var encodeTab, decodeTab = func() ([32]byte, [256]byte) {
-	const (
-		b32Chars   = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
-		b32UpToLow = ('a' - 'A')
-	)
-
+// newTables returns the encode and decode tables for the provided
+// alphabet and aliases.
+//
+// Letters are decoded case insensitively, so the alphabet must not
+// contain both the upper and lower case forms of a letter. Each alias
+// key decodes to the value of the alphabet symbol it maps to.
+//
+// This function panics if the alphabet is not exactly 32 bytes long,
+// if a symbol or alias is defined twice, or if an alias does not map
+// to a symbol of the alphabet.
+func newTables(alphabet string, aliases map[byte]byte) ([32]byte, [256]byte) {
 	var enc [32]byte
 	var dec [256]byte
 
+	if len(alphabet) != len(enc) {
+		panic("base32: encoding alphabet must be 32 bytes long")
+	}
+
 	for i := range dec {
 		dec[i] = b32Invalid
 	}
 
-	upLetter := func(v, i byte) {
+	set := func(v, i byte) {
+		if dec[v] != b32Invalid {
+			panic("base32: encoding symbol or alias defined more than once")
+		}
+
 		dec[v] = i
-		dec[v+b32UpToLow] = i
 	}
 
-	for i := range b32Chars {
+	setFolded := func(v, i byte) {
+		set(v, i)
+
+		switch {
+		case v >= 'A' && v <= 'Z':
+			set(v+b32UpToLow, i)
+		case v >= 'a' && v <= 'z':
+			set(v-b32UpToLow, i)
+		}
+	}
+
+	for i := range alphabet {
 		i := byte(i)
-		v := b32Chars[i]
+		v := alphabet[i]
 
 		enc[i] = v
-		if v > '9' {
-			upLetter(v, i)
-			continue
+		setFolded(v, i)
+	}
+
+	// char aliases, resolved against the alphabet alone so an alias can
+	// never map to another alias whatever the order of iteration
+	symbols := dec
+
+	for k, v := range aliases {
+		i := symbols[v]
+		if i == b32Invalid {
+			panic("base32: encoding alias does not map to an alphabet symbol")
 		}
 
-		dec[v] = i
+		setFolded(k, i)
 	}
 
-	// char aliases
-	upLetter('O', 0)
-	upLetter('I', 1)
-	upLetter('L', 1)
-
 	return enc, dec
-}()
+}
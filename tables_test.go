@@ -11,7 +11,7 @@ func TestTables(t *testing.T) {
 	t.Parallel()
 
 	const (
-		b32Chars         = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+		b32Chars         = crockfordAlphabet
 		invalidDecodeVal = byte(b32Invalid)
 	)
 
@@ -37,15 +37,56 @@ func TestTables(t *testing.T) {
 
 		uc, i := validChar(c)
 		if i == -1 {
-			is.Equal(invalidDecodeVal, decodeTab[c])
+			is.Equal(invalidDecodeVal, Crockford.decodeTab[c])
 			continue
 		}
 
-		is.Equal(i, int8(decodeTab[c]))
-		is.Equal(uc, encodeTab[i])
+		is.Equal(i, int8(Crockford.decodeTab[c]))
+		is.Equal(uc, Crockford.encodeTab[i])
 	}
 
 	// verify hardcoded alias values
-	is.Equal(uint8(0), decodeTab['0'])
-	is.Equal(uint8(1), decodeTab['1'])
+	is.Equal(uint8(0), Crockford.decodeTab['0'])
+	is.Equal(uint8(1), Crockford.decodeTab['1'])
+}
+
+func Test_newTables(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	is.PanicsWithValue("base32: encoding alphabet must be 32 bytes long", func() {
+		newTables(crockfordAlphabet[1:], nil)
+	})
+
+	is.PanicsWithValue("base32: encoding symbol or alias defined more than once", func() {
+		newTables("0"+crockfordAlphabet[1:31]+"0", nil)
+	})
+
+	is.PanicsWithValue("base32: encoding symbol or alias defined more than once", func() {
+		newTables(crockfordAlphabet[:31]+"y", nil)
+	})
+
+	is.PanicsWithValue("base32: encoding symbol or alias defined more than once", func() {
+		newTables(crockfordAlphabet, map[byte]byte{'a': '0'})
+	})
+
+	is.PanicsWithValue("base32: encoding alias does not map to an alphabet symbol", func() {
+		newTables(crockfordAlphabet, map[byte]byte{'U': 'O'})
+	})
+
+	// an alias of an alias is rejected whichever is applied first
+	for range 100 {
+		is.PanicsWithValue("base32: encoding alias does not map to an alphabet symbol", func() {
+			newTables(crockfordAlphabet, map[byte]byte{'I': '1', 'U': 'I'})
+		})
+	}
+
+	enc, dec := newTables("abcdefghijklmnopqrstuvwxyz234567", map[byte]byte{'0': 'O', '1': 'l'})
+	is.Equal(byte('a'), enc[0])
+	is.Equal(byte(0), dec['a'])
+	is.Equal(byte(0), dec['A'])
+	is.Equal(byte(14), dec['0'])
+	is.Equal(byte(11), dec['1'])
+	is.Equal(byte(b32Invalid), dec['8'])
 }
@@ -0,0 +1,185 @@
+package base32
+
+import "unsafe"
+
+// spareBits holds the number of unused low bits of the final symbol for
+// each remainder of encoded bytes modulo 5.
+var spareBits = [5]int{0, 2, 4, 1, 3}
+
+// TailBits returns the number of unused bits in the final symbol of the
+// encoded form of n bytes. It is 0, 1, 2, 3, or 4.
+func TailBits(n int) int {
+	if n < 0 {
+		return 0
+	}
+
+	return spareBits[n%5]
+}
+
+// EncodeWithTailBits returns the Crockford encoded form of src with bits
+// stored in the unused bits of its final symbol.
+//
+// See Encoding.EncodeWithTailBits for details.
+func EncodeWithTailBits(src []byte, bits uint8) []byte {
+	return Crockford.EncodeWithTailBits(src, bits)
+}
+
+// AppendEncodeWithTailBits returns the Crockford encoded form of src
+// with bits stored in the unused bits of its final symbol appended to
+// dst.
+//
+// See Encoding.EncodeWithTailBits for details.
+func AppendEncodeWithTailBits(dst, src []byte, bits uint8) []byte {
+	return Crockford.AppendEncodeWithTailBits(dst, src, bits)
+}
+
+// DecodeTailBits returns the Crockford decoded form of src along with
+// the value stored in the unused bits of its final symbol.
+//
+// See Encoding.DecodeTailBits for details.
+func DecodeTailBits(src []byte) ([]byte, uint8, int, error) {
+	return Crockford.DecodeTailBits(src)
+}
+
+// DecodeStringTailBits returns the Crockford decoded form of src along
+// with the value stored in the unused bits of its final symbol.
+//
+// See Encoding.DecodeTailBits for details.
+func DecodeStringTailBits(src string) ([]byte, uint8, int, error) {
+	return Crockford.DecodeStringTailBits(src)
+}
+
+// EncodeWithTailBits returns nil if src is empty, otherwise it returns
+// the encoded form of src with bits stored in the unused low bits of the
+// final symbol. TailBits(len(src)) reports how many bits are available.
+//
+// The result is only accepted by decoders that ignore the unused bits,
+// such as DecodeTailBits or an encoding created with WithLenientTail.
+//
+// This function panics if bits does not fit in the unused bits or if
+// enc is right-aligned.
+func (enc *Encoding) EncodeWithTailBits(src []byte, bits uint8) []byte {
+	return enc.AppendEncodeWithTailBits(nil, src, bits)
+}
+
+// AppendEncodeWithTailBits returns the encoded form of src with bits
+// stored in the unused low bits of the final symbol appended to dst if
+// src is not empty. If src is empty dst is returned as-is.
+//
+// See EncodeWithTailBits for details.
+func (enc *Encoding) AppendEncodeWithTailBits(dst, src []byte, bits uint8) []byte {
+	if enc.rightAligned {
+		panic("base32: right-aligned encoding has no tail bits")
+	}
+
+	n := len(src)
+
+	spare := TailBits(n)
+	if bits>>spare != 0 {
+		panic("base32: tail bits do not fit")
+	}
+
+	if n == 0 {
+		return dst
+	}
+
+	orig := len(dst)
+	dst = enc.AppendEncode(dst, src)
+
+	if spare != 0 {
+		// The final symbol holds the low bits of the last byte shifted
+		// up past the unused bits.
+		i := orig + encodedLenExpression(n) - 1
+		v := (src[n-1]<<spare | bits) & 31
+
+		if enc.constantTime {
+			dst[i] = enc.ctSymbol(v)
+		} else {
+			dst[i] = enc.encodeTab[v]
+		}
+	}
+
+	return dst
+}
+
+// DecodeTailBits returns the decoded form of src along with the value
+// bits held by the nbits unused low bits of its final symbol. nbits is
+// TailBits(len(data)). If src is empty nil, 0, 0, nil is returned.
+//
+// The input is decoded as if enc had been created with WithLenientTail;
+// every other rule is applied as usual.
+//
+// This function panics if enc is right-aligned.
+func (enc *Encoding) DecodeTailBits(src []byte) ([]byte, uint8, int, error) {
+	if len(src) == 0 {
+		return nil, 0, 0, nil
+	}
+
+	return enc.decodeTailBits(unsafe.Pointer(&src[0]), len(src))
+}
+
+// DecodeStringTailBits returns the decoded form of src along with the
+// value held by the unused low bits of its final symbol.
+//
+// See DecodeTailBits for details.
+func (enc *Encoding) DecodeStringTailBits(src string) ([]byte, uint8, int, error) {
+	if len(src) == 0 {
+		return nil, 0, 0, nil
+	}
+
+	return enc.decodeTailBits(unsafe.Pointer(unsafe.StringData(src)), len(src))
+}
+
+// decodeTailBits decodes the n bytes of encoded input at srcPtr and
+// extracts the unused bits of the final symbol.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) decodeTailBits(srcPtr unsafe.Pointer, n int) ([]byte, uint8, int, error) {
+	if enc.rightAligned {
+		panic("base32: right-aligned encoding has no tail bits")
+	}
+
+	lenient := enc
+	if !enc.lenientTail {
+		lenient = enc.WithLenientTail()
+	}
+
+	m, size, err := lenient.decodeLen(srcPtr, n)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if size == 0 {
+		return nil, 0, 0, nil
+	}
+
+	dst := make([]byte, size)
+
+	if err := lenient.decodeInput(unsafe.Pointer(&dst[0]), srcPtr, m); err != nil {
+		return dst, 0, 0, err
+	}
+
+	spare := TailBits(size)
+	if spare == 0 {
+		return dst, 0, 0, nil
+	}
+
+	// The final symbol is the last byte before any padding that is not
+	// a separator.
+	c := *(*byte)(unsafe.Add(srcPtr, m-1))
+	for enc.sepTab != nil && enc.sepTab[c] {
+		m--
+		c = *(*byte)(unsafe.Add(srcPtr, m-1))
+	}
+
+	v := enc.decodeTab[c]
+	if enc.constantTime {
+		v = enc.ctValue(c)
+	}
+
+	bits := v & (1<<spare - 1)
+
+	return dst, bits, spare, nil
+}
@@ -0,0 +1,130 @@
+package base32
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTailBits(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	is.Equal(0, TailBits(-1))
+	is.Equal(0, TailBits(0))
+	is.Equal(2, TailBits(1))
+	is.Equal(4, TailBits(2))
+	is.Equal(1, TailBits(3))
+	is.Equal(3, TailBits(4))
+	is.Equal(0, TailBits(5))
+	is.Equal(2, TailBits(6))
+
+	const src = "1234567890123456789"
+
+	for _, enc := range []*Encoding{Crockford, Crockford.Strict(), StdEncoding, Crockford.WithSeparators("-"), RawHexEncoding.ConstantTime()} {
+		for i := range len(src) + 1 {
+			data := []byte(src[:i])
+			nbits := TailBits(i)
+
+			for bits := range uint8(1) << nbits {
+				s := enc.EncodeWithTailBits(data, bits)
+				if i == 0 {
+					is.Nil(s)
+				}
+
+				is.Equal(string(s), string(enc.AppendEncodeWithTailBits(nil, data, bits)))
+				is.Equal("test_"+string(s), string(enc.AppendEncodeWithTailBits([]byte("test_"), data, bits)))
+
+				// only the tail bits differ from the regular encoding
+				b, err := enc.WithLenientTail().Decode(s)
+				is.Nil(err)
+				is.Equal(src[:i], string(b))
+
+				if bits == 0 {
+					is.Equal(enc.EncodeString(src[:i]), string(s))
+				} else {
+					_, err = enc.Decode(s)
+					is.ErrorIs(err, ErrNonCanonicalTail)
+				}
+
+				b, v, n, err := enc.DecodeTailBits(s)
+				is.Nil(err)
+				is.Equal(src[:i], string(b))
+				is.Equal(bits, v)
+				is.Equal(nbits, n)
+
+				b, v, n, err = enc.DecodeStringTailBits(string(s))
+				is.Nil(err)
+				is.Equal(src[:i], string(b))
+				is.Equal(bits, v)
+				is.Equal(nbits, n)
+
+				// a lenient encoding reports the same bits
+				_, v, _, err = enc.WithLenientTail().DecodeTailBits(s)
+				is.Nil(err)
+				is.Equal(bits, v)
+
+				if enc == Crockford {
+					is.Equal(s, EncodeWithTailBits(data, bits))
+					is.Equal("test_"+string(s), string(AppendEncodeWithTailBits([]byte("test_"), data, bits)))
+
+					b, v, n, err = DecodeTailBits(s)
+					is.Nil(err)
+					is.Equal(src[:i], string(b))
+					is.Equal(bits, v)
+					is.Equal(nbits, n)
+
+					b, v, n, err = DecodeStringTailBits(string(s))
+					is.Nil(err)
+					is.Equal(src[:i], string(b))
+					is.Equal(bits, v)
+					is.Equal(nbits, n)
+				}
+			}
+
+			is.PanicsWithValue("base32: tail bits do not fit", func() {
+				enc.EncodeWithTailBits(data, uint8(1)<<nbits)
+			})
+		}
+	}
+
+	// separators after the final symbol are skipped
+	hyphens := Crockford.WithSeparators("-")
+
+	b, v, n, err := hyphens.DecodeStringTailBits("64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GEF--")
+	is.Nil(err)
+	is.Equal(src, string(b))
+	is.Equal(uint8(7), v)
+	is.Equal(3, n)
+
+	b, v, n, err = hyphens.DecodeStringTailBits("--")
+	is.Nil(err)
+	is.Nil(b)
+	is.Zero(v)
+	is.Zero(n)
+
+	b, v, n, err = StdEncoding.WithSeparators("\n").DecodeStringTailBits("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJ\n=")
+	is.Nil(err)
+	is.Equal(src, string(b))
+	is.Equal(uint8(1), v)
+	is.Equal(3, n)
+
+	// every other rule still applies
+	for _, s := range []string{"000", "0U======", "M======="} {
+		_, _, _, err := StdEncoding.DecodeStringTailBits(s)
+		is.NotNil(err, s)
+	}
+
+	b, v, n, err = DecodeTailBits(nil)
+	is.Nil(err)
+	is.Nil(b)
+	is.Zero(v)
+	is.Zero(n)
+
+	b, v, n, err = DecodeStringTailBits("")
+	is.Nil(err)
+	is.Nil(b)
+	is.Zero(v)
+	is.Zero(n)
+}
@@ -0,0 +1,121 @@
+package base32
+
+import (
+	"crypto/rand"
+	"io"
+	"math"
+	"slices"
+	"unsafe"
+)
+
+// NewToken returns a random Crockford encoded token holding at least
+// bits bits of entropy read from crypto/rand.
+//
+// See Encoding.NewToken for details.
+func NewToken(bits int) (string, error) {
+	return Crockford.NewToken(bits)
+}
+
+// AppendToken returns a random Crockford encoded token holding at least
+// bits bits of entropy read from r appended to dst.
+//
+// See Encoding.AppendToken for details.
+func AppendToken(dst []byte, r io.Reader, bits int) ([]byte, error) {
+	return Crockford.AppendToken(dst, r, bits)
+}
+
+// TokenLength returns the length of a token holding at least bits bits
+// of entropy: the shortest encoded length of a whole number of bytes
+// holding that many bits. It returns -1 if bits is not positive.
+//
+// Every symbol but the last carries 5 bits, so the length is the
+// minimum possible for tokens that decode with zero tail bits.
+func TokenLength(bits int) int {
+	if bits <= 0 {
+		return -1
+	}
+
+	return encodedLenExpression((bits + 7) / 8)
+}
+
+// TokenEntropy returns the number of random bits held by a token of
+// length symbols, or -1 if no token has that length.
+func TokenEntropy(length int) int {
+	if length <= 0 {
+		return -1
+	}
+
+	n := decodedLen(length)
+	if n < 0 {
+		return -1
+	}
+
+	return n * 8
+}
+
+// CollisionProbability returns the probability that at least two of
+// population tokens, each holding bits bits of entropy, are equal.
+//
+// It is computed with the birthday bound 1 - exp(-k(k-1)/2^(bits+1)),
+// which is accurate for the small probabilities worth reporting.
+func CollisionProbability(bits int, population float64) float64 {
+	if population < 2 {
+		return 0
+	}
+
+	x := math.Ldexp(population*(population-1)/2, -bits)
+
+	return -math.Expm1(-x)
+}
+
+// NewToken returns a random token holding at least bits bits of entropy
+// read from crypto/rand.
+//
+// See AppendToken for details.
+func (enc *Encoding) NewToken(bits int) (string, error) {
+	b, err := enc.AppendToken(nil, nil, bits)
+
+	return string(b), err
+}
+
+// AppendToken reads (bits+7)/8 random bytes from r and appends their
+// encoded form, TokenLength(bits) symbols, to dst. If r is nil
+// crypto/rand.Reader is used.
+//
+// Tokens are never padded, even if enc is, so they decode with
+// enc.WithPadding(NoPadding). Their tail bits are zero so strict
+// decoders accept them too. The random bytes are cleared before
+// returning.
+//
+// If reading from r fails dst is returned as-is along with the error.
+//
+// This function panics if bits is not positive.
+func (enc *Encoding) AppendToken(dst []byte, r io.Reader, bits int) ([]byte, error) {
+	if bits <= 0 {
+		panic("base32: invalid token size")
+	}
+
+	if r == nil {
+		r = rand.Reader
+	}
+
+	n := (bits + 7) / 8
+	buf := make([]byte, n)
+	defer clear(buf)
+
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return dst, err
+	}
+
+	enc = enc.unpadded()
+
+	k := encodedLenExpression(n)
+	orig := len(dst)
+
+	dst = slices.Grow(dst, k)
+	dst = dst[:orig+k]
+
+	enc.encodeInput(unsafe.Pointer(&dst[orig]), unsafe.Pointer(&buf[0]), n)
+
+	return dst, nil
+}
@@ -0,0 +1,101 @@
+package base32
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	for _, tc := range []struct {
+		bits    int
+		length  int
+		entropy int
+	}{
+		{1, 2, 8},
+		{8, 2, 8},
+		{9, 4, 16},
+		{64, 13, 64},
+		{80, 16, 80},
+		{128, 26, 128},
+		{129, 28, 136},
+		{256, 52, 256},
+	} {
+		is.Equal(tc.length, TokenLength(tc.bits), tc.bits)
+		is.Equal(tc.entropy, TokenEntropy(tc.length), tc.bits)
+
+		// no shorter token holds that many bits
+		for k := tc.length - 1; k > 0; k-- {
+			is.Less(TokenEntropy(k), tc.bits, tc.bits)
+		}
+
+		for _, enc := range []*Encoding{Crockford, Crockford.Strict(), StdEncoding, Crockford.RightAligned(), RawHexEncoding.ConstantTime()} {
+			s, err := enc.NewToken(tc.bits)
+			is.Nil(err)
+			is.Len(s, tc.length)
+
+			b, err := enc.WithPadding(NoPadding).DecodeString(s)
+			is.Nil(err, s)
+			is.Len(b, tc.entropy/8, s)
+			is.True(enc.WithPadding(NoPadding).IsCanonicalString(s), s)
+		}
+
+		// the token is the encoded form of the bytes read
+		src := bytes.Repeat([]byte{0xA5}, tc.entropy/8+1)
+
+		b, err := AppendToken([]byte("tok_"), bytes.NewReader(src), tc.bits)
+		is.Nil(err)
+		is.Equal("tok_"+EncodeString(string(src[:tc.entropy/8])), string(b))
+	}
+
+	s, err := NewToken(128)
+	is.Nil(err)
+	is.Len(s, 26)
+	is.NotEqual(s, func() string { s, _ := NewToken(128); return s }())
+
+	b, err := AppendToken(nil, nil, 40)
+	is.Nil(err)
+	is.Len(b, 8)
+
+	// reading failures leave dst as-is
+	errRead := errors.New("read failed")
+
+	b, err = AppendToken([]byte("tok_"), bytes.NewReader([]byte{1, 2, 3}), 32)
+	is.ErrorIs(err, io.ErrUnexpectedEOF)
+	is.Equal("tok_", string(b))
+
+	b, err = AppendToken(nil, iotest.ErrReader(errRead), 32)
+	is.ErrorIs(err, errRead)
+	is.Nil(b)
+
+	is.PanicsWithValue("base32: invalid token size", func() {
+		_, _ = NewToken(0)
+	})
+
+	// lengths that are not valid
+	is.Equal(-1, TokenLength(0))
+	is.Equal(-1, TokenLength(-1))
+	is.Equal(-1, TokenEntropy(0))
+	is.Equal(-1, TokenEntropy(1))
+	is.Equal(-1, TokenEntropy(3))
+
+	// collision probabilities
+	is.Zero(CollisionProbability(128, 0))
+	is.Zero(CollisionProbability(128, 1))
+	is.Equal(1-math.Exp(-0.5), CollisionProbability(1, 2))
+	is.InDelta(1-math.Exp(-0.5), CollisionProbability(64, math.Ldexp(1, 32)+0.5), 1e-9)
+	is.InDelta(1.0, CollisionProbability(16, 1e6), 1e-12)
+
+	// a billion 128 bit tokens
+	p := CollisionProbability(TokenEntropy(26), 1e9)
+	is.InEpsilon(1e18/math.Ldexp(1, 129), p, 1e-6)
+}
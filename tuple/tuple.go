@@ -0,0 +1,284 @@
+// Package tuple encodes tuples of typed values as Crockford base32 keys
+// whose lexicographic order matches the order of the tuples.
+//
+// Each element is written as a type code followed by an order-preserving
+// form of its value. Byte slices and strings are terminated by a zero
+// byte, with zero bytes in the value escaped as 0x00 0xFF, so every
+// element is self-delimiting and a tuple sorts before any longer tuple it
+// is a prefix of. The resulting bytes are then encoded with the
+// Crockford codec of package base32, whose alphabet is in ascending byte
+// order.
+//
+// Tuples are compared element by element. Elements of different types
+// sort by type in this order: []byte, string, bool, integers, floats, and
+// times.
+package tuple
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/josephcopenhaver/base32"
+)
+
+// Type codes, in sort order.
+const (
+	codeBytes  = 0x01
+	codeString = 0x02
+	codeFalse  = 0x03
+	codeTrue   = 0x04
+	codeInt    = 0x05
+	codeFloat  = 0x06
+	codeTime   = 0x07
+)
+
+// escape follows a zero byte within a byte slice or string so it can be
+// told apart from the terminating zero byte.
+const escape = 0xFF
+
+var (
+	ErrUnsupportedType = errors.New("tuple: unsupported type")
+	ErrIntRange        = errors.New("tuple: unsigned integer out of range")
+	ErrInvalidTuple    = errors.New("tuple: invalid encoding")
+)
+
+// Pack returns the Crockford encoded key of the tuple elems.
+//
+// See AppendPack for details.
+func Pack(elems ...any) (string, error) {
+	b, err := AppendPack(nil, elems...)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// AppendPack appends the Crockford encoded key of the tuple elems to dst.
+//
+// The supported element types are []byte, string, bool, float32,
+// float64, time.Time, and every integer type. Integers are stored as
+// int64 so an unsigned value above math.MaxInt64 is rejected with
+// ErrIntRange. Floats are stored as float64 and times keep their instant
+// but not their location or monotonic clock reading.
+//
+// ErrUnsupportedType is returned, wrapped with the name of the type, for
+// any other element type. On error dst is returned as-is.
+func AppendPack(dst []byte, elems ...any) ([]byte, error) {
+	var raw []byte
+
+	for _, e := range elems {
+		var err error
+
+		raw, err = appendElem(raw, e)
+		if err != nil {
+			return dst, err
+		}
+	}
+
+	return base32.Crockford.AppendEncode(dst, raw), nil
+}
+
+// Unpack returns the elements of the tuple held by the key s as written
+// by Pack.
+//
+// Byte slices are returned as []byte, integers as int64, floats as
+// float64, and times as time.Time in UTC. An empty key holds the empty
+// tuple.
+//
+// A key that does not decode is reported with the *base32.DecodeError
+// of the decoder and one that decodes to a malformed tuple with
+// ErrInvalidTuple.
+func Unpack(s string) ([]any, error) {
+	raw, err := base32.Crockford.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var elems []any
+
+	for len(raw) > 0 {
+		var e any
+
+		e, raw, err = parseElem(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		elems = append(elems, e)
+	}
+
+	return elems, nil
+}
+
+// appendElem appends the type code and the order-preserving form of e to
+// b.
+func appendElem(b []byte, e any) ([]byte, error) {
+	switch v := e.(type) {
+	case []byte:
+		return appendEscaped(append(b, codeBytes), v), nil
+	case string:
+		return appendEscaped(append(b, codeString), v), nil
+	case bool:
+		if v {
+			return append(b, codeTrue), nil
+		}
+
+		return append(b, codeFalse), nil
+	case int:
+		return appendInt(b, int64(v)), nil
+	case int8:
+		return appendInt(b, int64(v)), nil
+	case int16:
+		return appendInt(b, int64(v)), nil
+	case int32:
+		return appendInt(b, int64(v)), nil
+	case int64:
+		return appendInt(b, v), nil
+	case uint:
+		return appendUint(b, uint64(v))
+	case uint8:
+		return appendInt(b, int64(v)), nil
+	case uint16:
+		return appendInt(b, int64(v)), nil
+	case uint32:
+		return appendInt(b, int64(v)), nil
+	case uint64:
+		return appendUint(b, v)
+	case float32:
+		return appendFloat(b, float64(v)), nil
+	case float64:
+		return appendFloat(b, v), nil
+	case time.Time:
+		b = append(b, codeTime)
+		b = binary.BigEndian.AppendUint64(b, uint64(v.Unix())^1<<63)
+		return binary.BigEndian.AppendUint32(b, uint32(v.Nanosecond())), nil
+	}
+
+	return b, fmt.Errorf("%w %T", ErrUnsupportedType, e)
+}
+
+func appendEscaped[T []byte | string](b []byte, v T) []byte {
+	for i := range len(v) {
+		c := v[i]
+
+		b = append(b, c)
+		if c == 0 {
+			b = append(b, escape)
+		}
+	}
+
+	return append(b, 0)
+}
+
+func appendInt(b []byte, v int64) []byte {
+	return binary.BigEndian.AppendUint64(append(b, codeInt), uint64(v)^1<<63)
+}
+
+func appendUint(b []byte, v uint64) ([]byte, error) {
+	if v > math.MaxInt64 {
+		return b, ErrIntRange
+	}
+
+	return appendInt(b, int64(v)), nil
+}
+
+func appendFloat(b []byte, f float64) []byte {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits ^= 1 << 63
+	}
+
+	return binary.BigEndian.AppendUint64(append(b, codeFloat), bits)
+}
+
+// parseElem returns the element at the start of b along with the bytes
+// that follow it.
+//
+// invariants:
+//
+// - len(b) > 0
+func parseElem(b []byte) (any, []byte, error) {
+	code, b := b[0], b[1:]
+
+	switch code {
+	case codeBytes, codeString:
+		v, rest, err := parseEscaped(b)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if code == codeString {
+			return string(v), rest, nil
+		}
+
+		return v, rest, nil
+	case codeFalse:
+		return false, b, nil
+	case codeTrue:
+		return true, b, nil
+	case codeInt:
+		if len(b) < 8 {
+			break
+		}
+
+		return int64(binary.BigEndian.Uint64(b) ^ 1<<63), b[8:], nil
+	case codeFloat:
+		if len(b) < 8 {
+			break
+		}
+
+		bits := binary.BigEndian.Uint64(b)
+		if bits&(1<<63) != 0 {
+			bits ^= 1 << 63
+		} else {
+			bits = ^bits
+		}
+
+		return math.Float64frombits(bits), b[8:], nil
+	case codeTime:
+		if len(b) < 12 {
+			break
+		}
+
+		sec := int64(binary.BigEndian.Uint64(b) ^ 1<<63)
+
+		nsec := binary.BigEndian.Uint32(b[8:])
+		if nsec >= 1e9 {
+			break
+		}
+
+		return time.Unix(sec, int64(nsec)).UTC(), b[12:], nil
+	}
+
+	return nil, nil, ErrInvalidTuple
+}
+
+// parseEscaped returns the unescaped value at the start of b along with
+// the bytes that follow its terminating zero byte.
+func parseEscaped(b []byte) ([]byte, []byte, error) {
+	v := []byte{}
+
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if c != 0 {
+			v = append(v, c)
+			continue
+		}
+
+		if i+1 < len(b) && b[i+1] == escape {
+			v = append(v, 0)
+			i++
+			continue
+		}
+
+		return v, b[i+1:], nil
+	}
+
+	return nil, nil, ErrInvalidTuple
+}
@@ -0,0 +1,203 @@
+package tuple
+
+import (
+	"bytes"
+	"cmp"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/josephcopenhaver/base32"
+	"github.com/stretchr/testify/assert"
+)
+
+// rank returns the sort order of the type of an unpacked element.
+func rank(e any) int {
+	switch e.(type) {
+	case []byte:
+		return 0
+	case string:
+		return 1
+	case bool:
+		return 2
+	case int64:
+		return 3
+	case float64:
+		return 4
+	}
+
+	return 5
+}
+
+// compareTuples is the reference order of unpacked tuples.
+func compareTuples(a, b []any) int {
+	for i := range min(len(a), len(b)) {
+		if c := cmp.Compare(rank(a[i]), rank(b[i])); c != 0 {
+			return c
+		}
+
+		var c int
+		switch x := a[i].(type) {
+		case []byte:
+			c = bytes.Compare(x, b[i].([]byte))
+		case string:
+			c = strings.Compare(x, b[i].(string))
+		case bool:
+			c = cmp.Compare(b2i(x), b2i(b[i].(bool)))
+		case int64:
+			c = cmp.Compare(x, b[i].(int64))
+		case float64:
+			y := b[i].(float64)
+			c = cmp.Compare(x, y)
+			if c == 0 {
+				// -0 before +0
+				c = cmp.Compare(b2i(math.Signbit(y)), b2i(math.Signbit(x)))
+			}
+		case time.Time:
+			c = x.Compare(b[i].(time.Time))
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+func b2i(v bool) int {
+	if v {
+		return 1
+	}
+
+	return 0
+}
+
+func TestPack(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	r := rand.New(rand.NewPCG(13, 14))
+
+	// small alphabets make shared prefixes and zero bytes likely
+	randElem := func() any {
+		switch r.IntN(7) {
+		case 0:
+			b := make([]byte, r.IntN(4))
+			for i := range b {
+				b[i] = []byte{0, 1, 0xFF}[r.IntN(3)]
+			}
+			return b
+		case 1:
+			return string([]byte("\x00a\xFF")[r.IntN(3):][:r.IntN(2)]) + strings.Repeat("b", r.IntN(3))
+		case 2:
+			return r.IntN(2) == 1
+		case 3:
+			return int64(r.IntN(5)-2) << (r.IntN(2) * 62)
+		case 4:
+			return []float64{math.Inf(-1), -1.5, math.Copysign(0, -1), 0, 2.5, math.Inf(1)}[r.IntN(6)]
+		}
+		return time.Unix(int64(r.IntN(5)-2), int64(r.IntN(3))).UTC()
+	}
+
+	tuples := [][]any{{}}
+	for range 2000 {
+		tup := make([]any, r.IntN(4))
+		for i := range tup {
+			tup[i] = randElem()
+		}
+		tuples = append(tuples, tup)
+	}
+
+	keys := map[string][]any{}
+	for _, tup := range tuples {
+		k, err := Pack(tup...)
+		is.Nil(err)
+		is.Nil(base32.Crockford.Strict().ValidString(k), k)
+
+		got, err := Unpack(k)
+		is.Nil(err, k)
+		if len(tup) == 0 {
+			is.Nil(got, k)
+		} else {
+			is.Equal(tup, got, k)
+		}
+
+		keys[k] = tup
+	}
+
+	// key order matches tuple order
+	sorted := slices.Sorted(maps.Keys(keys))
+
+	for i := 1; i < len(sorted); i++ {
+		a, b := keys[sorted[i-1]], keys[sorted[i]]
+		is.Equal(-1, compareTuples(a, b), "%v >= %v", a, b)
+	}
+
+	// every integer type and float32
+	k, err := Pack(int(-1), int8(-2), int16(-3), int32(-4), int64(-5), uint(1), uint8(2), uint16(3), uint32(4), uint64(math.MaxInt64), float32(0.5))
+	is.Nil(err)
+
+	got, err := Unpack(k)
+	is.Nil(err)
+	is.Equal([]any{int64(-1), int64(-2), int64(-3), int64(-4), int64(-5), int64(1), int64(2), int64(3), int64(4), int64(math.MaxInt64), 0.5}, got)
+
+	// times are returned in UTC
+	tm := time.Date(2026, 10, 16, 12, 0, 0, 5, time.FixedZone("X", 3600))
+	k, err = Pack("tenant", tm)
+	is.Nil(err)
+
+	got, err = Unpack(k)
+	is.Nil(err)
+	is.Equal([]any{"tenant", tm.UTC()}, got)
+
+	b, err := AppendPack([]byte("idx/"), "tenant")
+	is.Nil(err)
+	is.Equal("idx/"+k[:len(base32.Crockford.EncodeString("\x02tenant\x00"))], string(b))
+
+	// errors
+	for _, tc := range []struct {
+		elem any
+		err  error
+		msg  string
+	}{
+		{uint(math.MaxInt64 + 1), ErrIntRange, "tuple: unsigned integer out of range"},
+		{uint64(math.MaxUint64), ErrIntRange, "tuple: unsigned integer out of range"},
+		{nil, ErrUnsupportedType, "tuple: unsupported type <nil>"},
+		{struct{}{}, ErrUnsupportedType, "tuple: unsupported type struct {}"},
+	} {
+		k, err := Pack("a", tc.elem)
+		is.ErrorIs(err, tc.err)
+		is.Equal(tc.msg, err.Error())
+		is.Empty(k)
+
+		b, err := AppendPack([]byte("idx/"), tc.elem)
+		is.ErrorIs(err, tc.err)
+		is.Equal("idx/", string(b))
+	}
+
+	_, err = Unpack("0U")
+	is.Equal(&base32.DecodeError{Offset: 1, Char: 'U', Kind: base32.InvalidChar}, err)
+
+	for _, raw := range []string{
+		"\x00",
+		"\x08",
+		"\x01",
+		"\x02ab",
+		"\x02a\x00\xFF",
+		"\x05\x00",
+		"\x06\x00",
+		"\x07\x00",
+		"\x07\x80\x00\x00\x00\x00\x00\x00\x00\x3B\x9A\xCA\x00",
+		"\x03\x05",
+	} {
+		got, err := Unpack(base32.Crockford.EncodeString(raw))
+		is.Equal(ErrInvalidTuple, err, raw)
+		is.Nil(got, raw)
+	}
+}
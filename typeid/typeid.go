@@ -0,0 +1,268 @@
+// Package typeid implements TypeIDs: type-safe identifiers formed of a
+// type prefix and a UUID encoded as 26 lowercase Crockford symbols, such
+// as "user_01h455vb4pex5vsknk084sn02q".
+//
+// The suffix is decoded strictly: only the lowercase symbols the encoder
+// emits are accepted and a leading symbol above '7', which would
+// overflow 128 bits, is rejected.
+package typeid
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/josephcopenhaver/base32"
+)
+
+// SuffixSize is the length of the suffix of a TypeID.
+const SuffixSize = 26
+
+// MaxPrefixSize is the length of the longest valid prefix.
+const MaxPrefixSize = 63
+
+var (
+	ErrInvalidPrefix  = errors.New("typeid: invalid prefix")
+	ErrPrefixMismatch = errors.New("typeid: prefix mismatch")
+	ErrInvalidSuffix  = errors.New("typeid: invalid suffix")
+	ErrScanValue      = errors.New("typeid: scan source must be a string or byte slice")
+)
+
+// encoding emits the lowercase symbols the specification requires and
+// accepts nothing else.
+var encoding = base32.NewEncoding(strings.ToLower("0123456789ABCDEFGHJKMNPQRSTVWXYZ"), nil).Strict().RightAligned()
+
+// Prefix is implemented by the types naming the prefix of a TypeID. The
+// method is called on the zero value of the type, for example:
+//
+//	type UserPrefix struct{}
+//
+//	func (UserPrefix) Prefix() string { return "user" }
+//
+//	type UserID = typeid.TypeID[UserPrefix]
+type Prefix interface {
+	Prefix() string
+}
+
+// TypeID is an identifier whose prefix is given by P.
+//
+// Its zero value holds the Nil UUID, as in "user_00000000000000000000000000".
+type TypeID[P Prefix] struct {
+	uuid [16]byte
+}
+
+// New returns a TypeID holding a new version 7 UUID for the current
+// time.
+//
+// This function panics if the prefix of P is not valid.
+func New[P Prefix]() TypeID[P] {
+	var u [16]byte
+
+	ms := uint64(time.Now().UnixMilli())
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+
+	_, _ = rand.Read(u[6:])
+
+	u[6] = u[6]&0x0F | 0x70 // version 7
+	u[8] = u[8]&0x3F | 0x80 // RFC 9562 variant
+
+	return FromUUID[P](u)
+}
+
+// FromUUID returns the TypeID holding the UUID u.
+//
+// This function panics if the prefix of P is not valid.
+func FromUUID[P Prefix](u [16]byte) TypeID[P] {
+	prefixOf[P]()
+
+	return TypeID[P]{uuid: u}
+}
+
+// Parse returns the TypeID held by s, whose prefix must be the prefix of
+// P.
+//
+// ErrPrefixMismatch is returned if s holds a different but valid prefix
+// and ErrInvalidPrefix or ErrInvalidSuffix if either part is malformed.
+// A suffix of the right length that the strict decoder rejects is
+// reported with a *base32.DecodeError whose offset is relative to s.
+func Parse[P Prefix](s string) (TypeID[P], error) {
+	prefix, u, err := Split(s)
+	if err != nil {
+		return TypeID[P]{}, err
+	}
+
+	if prefix != prefixOf[P]() {
+		return TypeID[P]{}, ErrPrefixMismatch
+	}
+
+	return TypeID[P]{uuid: u}, nil
+}
+
+// MustParse is like Parse but panics if s can not be parsed.
+func MustParse[P Prefix](s string) TypeID[P] {
+	id, err := Parse[P](s)
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+// Split returns the prefix and the UUID held by the TypeID s whatever
+// its prefix.
+//
+// The prefix is everything before the last underscore. If s has no
+// underscore the prefix is empty and s is the whole suffix.
+func Split(s string) (string, [16]byte, error) {
+	var prefix string
+	suffix := s
+
+	if i := strings.LastIndexByte(s, '_'); i >= 0 {
+		prefix, suffix = s[:i], s[i+1:]
+
+		if prefix == "" {
+			return "", [16]byte{}, ErrInvalidPrefix
+		}
+
+		if err := ValidPrefix(prefix); err != nil {
+			return "", [16]byte{}, err
+		}
+	}
+
+	if len(suffix) != SuffixSize {
+		return "", [16]byte{}, ErrInvalidSuffix
+	}
+
+	var u [16]byte
+
+	if err := encoding.DecodeStringInto(u[:], suffix); err != nil {
+		err.(*base32.DecodeError).Offset += int64(len(s) - len(suffix))
+		return "", [16]byte{}, err
+	}
+
+	return prefix, u, nil
+}
+
+// ValidPrefix returns nil if prefix is a valid TypeID prefix: empty, or
+// at most 63 lowercase ASCII letters and underscores starting and ending
+// with a letter. Otherwise ErrInvalidPrefix is returned.
+func ValidPrefix(prefix string) error {
+	n := len(prefix)
+	if n == 0 {
+		return nil
+	}
+
+	if n > MaxPrefixSize || prefix[0] == '_' || prefix[n-1] == '_' {
+		return ErrInvalidPrefix
+	}
+
+	for i := range n {
+		if c := prefix[i]; (c < 'a' || c > 'z') && c != '_' {
+			return ErrInvalidPrefix
+		}
+	}
+
+	return nil
+}
+
+// prefixOf returns the prefix of P.
+//
+// This function panics if the prefix is not valid.
+func prefixOf[P Prefix]() string {
+	var p P
+
+	prefix := p.Prefix()
+	if ValidPrefix(prefix) != nil {
+		panic(ErrInvalidPrefix)
+	}
+
+	return prefix
+}
+
+// Prefix returns the prefix of id.
+func (id TypeID[P]) Prefix() string {
+	return prefixOf[P]()
+}
+
+// Suffix returns the 26 symbol encoded UUID of id.
+func (id TypeID[P]) Suffix() string {
+	var b [SuffixSize]byte
+
+	encoding.UnsafeEncode(b[:], id.uuid[:])
+
+	return string(b[:])
+}
+
+// UUID returns the UUID held by id.
+func (id TypeID[P]) UUID() [16]byte {
+	return id.uuid
+}
+
+// IsZero reports whether id holds the Nil UUID.
+func (id TypeID[P]) IsZero() bool {
+	return id.uuid == [16]byte{}
+}
+
+// String returns the prefix of id, an underscore, and the suffix of id,
+// or just the suffix if the prefix is empty.
+func (id TypeID[P]) String() string {
+	b, _ := id.AppendText(nil)
+
+	return string(b)
+}
+
+// AppendText appends the text form of id to b.
+func (id TypeID[P]) AppendText(b []byte) ([]byte, error) {
+	if prefix := prefixOf[P](); prefix != "" {
+		b = append(b, prefix...)
+		b = append(b, '_')
+	}
+
+	return encoding.AppendEncode(b, id.uuid[:]), nil
+}
+
+// MarshalText returns the text form of id. It is also used when
+// marshalling id to JSON.
+func (id TypeID[P]) MarshalText() ([]byte, error) {
+	return id.AppendText(nil)
+}
+
+// UnmarshalText parses b as if by Parse.
+func (id *TypeID[P]) UnmarshalText(b []byte) error {
+	v, err := Parse[P](string(b))
+	if err != nil {
+		return err
+	}
+
+	*id = v
+
+	return nil
+}
+
+// Value implements driver.Valuer by returning the text form of id.
+func (id TypeID[P]) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner by parsing a string or byte slice as if by
+// Parse. A nil value sets the zero TypeID.
+func (id *TypeID[P]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = TypeID[P]{}
+		return nil
+	case string:
+		return id.UnmarshalText([]byte(v))
+	case []byte:
+		return id.UnmarshalText(v)
+	}
+
+	return ErrScanValue
+}
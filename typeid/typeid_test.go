@@ -0,0 +1,203 @@
+package typeid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/josephcopenhaver/base32"
+	"github.com/stretchr/testify/assert"
+)
+
+type userPrefix struct{}
+
+func (userPrefix) Prefix() string { return "user" }
+
+type fixPrefix struct{}
+
+func (fixPrefix) Prefix() string { return "pre_fix" }
+
+type noPrefix struct{}
+
+func (noPrefix) Prefix() string { return "" }
+
+type badPrefix struct{}
+
+func (badPrefix) Prefix() string { return "User" }
+
+func TestTypeID(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	// the example from the specification
+	const s = "user_01h455vb4pex5vsknk084sn02q"
+	uuid := [16]byte{0x01, 0x89, 0x0a, 0x5d, 0xac, 0x96, 0x77, 0x4b, 0xbc, 0xce, 0xb3, 0x02, 0x09, 0x9a, 0x80, 0x57}
+
+	id, err := Parse[userPrefix](s)
+	is.Nil(err)
+	is.Equal(uuid, id.UUID())
+	is.Equal("user", id.Prefix())
+	is.Equal("01h455vb4pex5vsknk084sn02q", id.Suffix())
+	is.Equal(s, id.String())
+	is.False(id.IsZero())
+	is.Equal(id, FromUUID[userPrefix](uuid))
+	is.Equal(id, MustParse[userPrefix](s))
+
+	// other valid forms from the specification
+	for _, tc := range []struct {
+		src    string
+		prefix string
+		uuid   [16]byte
+	}{
+		{"00000000000000000000000000", "", [16]byte{}},
+		{"00000000000000000000000001", "", [16]byte{15: 1}},
+		{"0000000000000000000000000a", "", [16]byte{15: 10}},
+		{"7zzzzzzzzzzzzzzzzzzzzzzzzz", "", [16]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{"pre_fix_00000000000000000000000000", "pre_fix", [16]byte{}},
+		{strings.Repeat("a", 63) + "_00000000000000000000000000", strings.Repeat("a", 63), [16]byte{}},
+	} {
+		prefix, u, err := Split(tc.src)
+		is.Nil(err, tc.src)
+		is.Equal(tc.prefix, prefix, tc.src)
+		is.Equal(tc.uuid, u, tc.src)
+	}
+
+	v, err := Parse[noPrefix]("00000000000000000000000001")
+	is.Nil(err)
+	is.Equal("00000000000000000000000001", v.String())
+	is.Equal("", v.Prefix())
+
+	w, err := Parse[fixPrefix]("pre_fix_00000000000000000000000000")
+	is.Nil(err)
+	is.True(w.IsZero())
+	is.Equal("pre_fix_00000000000000000000000000", w.String())
+
+	is.Equal("user_00000000000000000000000000", TypeID[userPrefix]{}.String())
+
+	// invalid input
+	for _, tc := range []struct {
+		src string
+		err error
+	}{
+		{"", ErrInvalidSuffix},
+		{"user_", ErrInvalidSuffix},
+		{"user_01h455vb4pex5vsknk084sn02", ErrInvalidSuffix},
+		{"user_01h455vb4pex5vsknk084sn02qq", ErrInvalidSuffix},
+		{"_01h455vb4pex5vsknk084sn02q", ErrInvalidPrefix},
+		{"_user_01h455vb4pex5vsknk084sn02q", ErrInvalidPrefix},
+		{"user__01h455vb4pex5vsknk084sn02q", ErrInvalidPrefix},
+		{"User_01h455vb4pex5vsknk084sn02q", ErrInvalidPrefix},
+		{"us3r_01h455vb4pex5vsknk084sn02q", ErrInvalidPrefix},
+		{"us-r_01h455vb4pex5vsknk084sn02q", ErrInvalidPrefix},
+		{strings.Repeat("a", 64) + "_01h455vb4pex5vsknk084sn02q", ErrInvalidPrefix},
+		{"user_81h455vb4pex5vsknk084sn02q", &base32.DecodeError{Offset: 5, Char: '8', Kind: base32.Overflow}},
+		{"user_01H455vb4pex5vsknk084sn02q", &base32.DecodeError{Offset: 7, Char: 'H', Kind: base32.NonCanonicalChar}},
+		{"user_01h455vb4pex5vsknk084sn02u", &base32.DecodeError{Offset: 30, Char: 'u', Kind: base32.InvalidChar}},
+		{"user_01h455vb4pex5vsknk084sno2q", &base32.DecodeError{Offset: 28, Char: 'o', Kind: base32.InvalidChar}},
+		{"user-01h455vb4pex5vsknk084sn02q", ErrInvalidSuffix},
+		{"post_01h455vb4pex5vsknk084sn02q", ErrPrefixMismatch},
+		{"01h455vb4pex5vsknk084sn02q", ErrPrefixMismatch},
+	} {
+		id, err := Parse[userPrefix](tc.src)
+		is.Equal(tc.err, err, tc.src)
+		is.Zero(id, tc.src)
+	}
+
+	is.PanicsWithValue(ErrPrefixMismatch, func() {
+		MustParse[userPrefix]("post_01h455vb4pex5vsknk084sn02q")
+	})
+
+	// prefixes that are not valid are programming errors
+	is.PanicsWithValue(ErrInvalidPrefix, func() {
+		FromUUID[badPrefix](uuid)
+	})
+	is.PanicsWithValue(ErrInvalidPrefix, func() {
+		New[badPrefix]()
+	})
+	is.PanicsWithValue(ErrInvalidPrefix, func() {
+		_, _ = Parse[badPrefix]("user_01h455vb4pex5vsknk084sn02q")
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	before := time.Now().UnixMilli()
+	id := New[userPrefix]()
+	after := time.Now().UnixMilli()
+
+	u := id.UUID()
+	is.Nil(base32.ValidUUID(u))
+	is.Equal(7, base32.UUIDVersion(u))
+
+	ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	is.LessOrEqual(before, ms)
+	is.GreaterOrEqual(after, ms)
+
+	is.True(strings.HasPrefix(id.String(), "user_0"))
+	is.Equal(id, MustParse[userPrefix](id.String()))
+
+	is.NotEqual(id, New[userPrefix]())
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const s = "user_01h455vb4pex5vsknk084sn02q"
+	id := MustParse[userPrefix](s)
+
+	// text
+	b, err := id.MarshalText()
+	is.Nil(err)
+	is.Equal(s, string(b))
+
+	b, err = id.AppendText([]byte("id="))
+	is.Nil(err)
+	is.Equal("id="+s, string(b))
+
+	var v TypeID[userPrefix]
+	is.Nil(v.UnmarshalText([]byte(s)))
+	is.Equal(id, v)
+
+	is.Equal(ErrPrefixMismatch, v.UnmarshalText([]byte("post_01h455vb4pex5vsknk084sn02q")))
+	is.Equal(id, v)
+
+	// JSON
+	type doc struct {
+		ID TypeID[userPrefix] `json:"id"`
+	}
+
+	b, err = json.Marshal(doc{id})
+	is.Nil(err)
+	is.Equal(`{"id":"`+s+`"}`, string(b))
+
+	var d doc
+	is.Nil(json.Unmarshal(b, &d))
+	is.Equal(id, d.ID)
+
+	is.NotNil(json.Unmarshal([]byte(`{"id":"user_81h455vb4pex5vsknk084sn02q"}`), &d))
+
+	// SQL
+	dv, err := id.Value()
+	is.Nil(err)
+	is.Equal(s, dv)
+
+	for _, src := range []any{s, []byte(s)} {
+		v := TypeID[userPrefix]{}
+		is.Nil(v.Scan(src), src)
+		is.Equal(id, v, src)
+	}
+
+	v = id
+	is.Nil(v.Scan(nil))
+	is.True(v.IsZero())
+
+	is.Equal(ErrScanValue, v.Scan(42))
+	is.Equal(ErrInvalidSuffix, v.Scan("user_0"))
+}
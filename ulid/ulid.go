@@ -0,0 +1,299 @@
+// Package ulid implements Universally Unique Lexicographically Sortable
+// Identifiers on top of the strict Crockford codec of package base32.
+//
+// A ULID is a 48 bit big-endian millisecond Unix timestamp followed by
+// 80 bits of randomness, encoded as 26 right-aligned Crockford symbols.
+package ulid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/josephcopenhaver/base32"
+)
+
+// EncodedSize is the length of the text form of a ULID.
+const EncodedSize = 26
+
+// maxTime is the largest millisecond timestamp a ULID can hold.
+const maxTime = 1<<48 - 1
+
+var (
+	ErrTimeRange         = errors.New("ulid: time out of range")
+	ErrMonotonicOverflow = errors.New("ulid: monotonic entropy overflow")
+	ErrDataSize          = errors.New("ulid: invalid data size")
+	ErrScanValue         = errors.New("ulid: scan source must be a string or byte slice")
+)
+
+var (
+	// encoding accepts lowercase letters and the Crockford aliases but
+	// rejects values that overflow 128 bits.
+	encoding = base32.Crockford.RightAligned()
+
+	// strictEncoding only accepts the canonical form.
+	strictEncoding = encoding.Strict()
+)
+
+// ULID is a Universally Unique Lexicographically Sortable Identifier.
+//
+// Its zero value is the ULID "00000000000000000000000000".
+type ULID [16]byte
+
+// New returns a ULID holding the time t, truncated to milliseconds,
+// followed by 10 bytes read from entropy. If entropy is nil
+// crypto/rand.Reader is used.
+//
+// ErrTimeRange is returned if t is before the Unix epoch or after the
+// last millisecond a ULID can hold.
+func New(t time.Time, entropy io.Reader) (ULID, error) {
+	var id ULID
+
+	ms, err := timestamp(t)
+	if err != nil {
+		return id, err
+	}
+
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+
+	id.setTimestamp(ms)
+
+	if _, err := io.ReadFull(entropy, id[6:]); err != nil {
+		return ULID{}, err
+	}
+
+	return id, nil
+}
+
+// Parse returns the ULID held by the 26 symbols of s.
+//
+// Lowercase letters and the Crockford aliases O, I, and L are accepted.
+// Values that overflow 128 bits are rejected along with any other input
+// the base32 decoder rejects, in which case the error is a
+// *base32.DecodeError. ErrDataSize is returned if s is not 26 bytes
+// long.
+func Parse(s string) (ULID, error) {
+	return parse(encoding, s)
+}
+
+// ParseStrict returns the ULID held by the 26 symbols of s, which must
+// be in canonical form: uppercase with no aliases.
+//
+// See Parse for details.
+func ParseStrict(s string) (ULID, error) {
+	return parse(strictEncoding, s)
+}
+
+// MustParse is like Parse but panics if s can not be parsed.
+func MustParse(s string) ULID {
+	id, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+func parse(enc *base32.Encoding, s string) (ULID, error) {
+	var id ULID
+
+	if len(s) != EncodedSize {
+		return id, ErrDataSize
+	}
+
+	if err := enc.DecodeStringInto(id[:], s); err != nil {
+		return ULID{}, err
+	}
+
+	return id, nil
+}
+
+// Timestamp returns the number of milliseconds since the Unix epoch held
+// by id.
+func (id ULID) Timestamp() uint64 {
+	return uint64(id[0])<<40 | uint64(id[1])<<32 | uint64(id[2])<<24 |
+		uint64(id[3])<<16 | uint64(id[4])<<8 | uint64(id[5])
+}
+
+// Time returns the time held by id.
+func (id ULID) Time() time.Time {
+	return time.UnixMilli(int64(id.Timestamp()))
+}
+
+// Entropy returns a copy of the random bytes of id.
+func (id ULID) Entropy() []byte {
+	return bytes.Clone(id[6:])
+}
+
+// Compare returns -1, 0, or +1 depending on whether id sorts before, the
+// same as, or after other.
+func (id ULID) Compare(other ULID) int {
+	return bytes.Compare(id[:], other[:])
+}
+
+// String returns the canonical 26 symbol form of id.
+func (id ULID) String() string {
+	var b [EncodedSize]byte
+
+	encoding.UnsafeEncode(b[:], id[:])
+
+	return string(b[:])
+}
+
+// AppendText appends the canonical form of id to b.
+func (id ULID) AppendText(b []byte) ([]byte, error) {
+	return encoding.AppendEncode(b, id[:]), nil
+}
+
+// MarshalText returns the canonical form of id. It is also used when
+// marshalling id to JSON.
+func (id ULID) MarshalText() ([]byte, error) {
+	return id.AppendText(make([]byte, 0, EncodedSize))
+}
+
+// UnmarshalText parses b as if by Parse.
+func (id *ULID) UnmarshalText(b []byte) error {
+	v, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+
+	*id = v
+
+	return nil
+}
+
+// MarshalBinary returns the 16 bytes of id.
+func (id ULID) MarshalBinary() ([]byte, error) {
+	return bytes.Clone(id[:]), nil
+}
+
+// UnmarshalBinary sets id to the 16 bytes of b. ErrDataSize is returned
+// if b is not 16 bytes long.
+func (id *ULID) UnmarshalBinary(b []byte) error {
+	if len(b) != len(id) {
+		return ErrDataSize
+	}
+
+	copy(id[:], b)
+
+	return nil
+}
+
+// Value implements driver.Valuer by returning the canonical form of id.
+func (id ULID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner. A string or a 26 byte slice is parsed as
+// text while a 16 byte slice is taken as the binary form. A nil value
+// sets the zero ULID.
+func (id *ULID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = ULID{}
+		return nil
+	case string:
+		return id.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == len(id) {
+			return id.UnmarshalBinary(v)
+		}
+
+		return id.UnmarshalText(v)
+	}
+
+	return ErrScanValue
+}
+
+func (id *ULID) setTimestamp(ms uint64) {
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+}
+
+func timestamp(t time.Time) (uint64, error) {
+	ms := t.UnixMilli()
+	if ms < 0 || ms > maxTime {
+		return 0, ErrTimeRange
+	}
+
+	return uint64(ms), nil
+}
+
+// Monotonic generates strictly increasing ULIDs.
+//
+// Within the same millisecond, or if the clock moves backwards, each
+// ULID reuses the timestamp of the previous one and increments its
+// random bytes by one rather than reading new entropy. It is safe for
+// concurrent use.
+type Monotonic struct {
+	mu      sync.Mutex
+	entropy io.Reader
+	last    ULID
+}
+
+// NewMonotonic returns a Monotonic generator reading entropy from r. If
+// r is nil crypto/rand.Reader is used.
+func NewMonotonic(r io.Reader) *Monotonic {
+	if r == nil {
+		r = rand.Reader
+	}
+
+	return &Monotonic{entropy: r}
+}
+
+// New returns a ULID for the time t that sorts after every ULID
+// previously returned by m.
+//
+// ErrMonotonicOverflow is returned if the random bytes can not be
+// incremented any further within the current millisecond and
+// ErrTimeRange is returned if t can not be held by a ULID.
+func (m *Monotonic) New(t time.Time) (ULID, error) {
+	ms, err := timestamp(t)
+	if err != nil {
+		return ULID{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if last := m.last.Timestamp(); ms <= last && m.last != (ULID{}) {
+		id := m.last
+
+		// Increment the 80 bit random part.
+		i := len(id) - 1
+		for ; i >= 6; i-- {
+			id[i]++
+			if id[i] != 0 {
+				break
+			}
+		}
+
+		if i < 6 {
+			return ULID{}, ErrMonotonicOverflow
+		}
+
+		m.last = id
+
+		return id, nil
+	}
+
+	id, err := New(t, m.entropy)
+	if err != nil {
+		return ULID{}, err
+	}
+
+	m.last = id
+
+	return id, nil
+}
@@ -0,0 +1,311 @@
+package ulid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/josephcopenhaver/base32"
+	"github.com/stretchr/testify/assert"
+)
+
+// errReader fails every read.
+type errReader struct{}
+
+var errRead = errors.New("read failed")
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
+
+func TestULID(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	// the example from the specification
+	const s = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	id, err := Parse(s)
+	is.Nil(err)
+	is.Equal(uint64(1469922850259), id.Timestamp())
+	is.Equal(time.UnixMilli(1469922850259), id.Time())
+	is.Equal(s, id.String())
+	is.Equal(id[6:], id.Entropy())
+	is.Equal(id, MustParse(s))
+
+	// lowercase and aliases are accepted by Parse but not ParseStrict
+	for _, in := range []string{strings.ToLower(s), "O1ARZ3NDEKTSV4RRFFQ69G5FAV"} {
+		v, err := Parse(in)
+		is.Nil(err, in)
+		is.Equal(id, v, in)
+
+		_, err = ParseStrict(in)
+		is.ErrorIs(err, base32.ErrNonCanonicalChar, in)
+	}
+
+	v, err := ParseStrict(s)
+	is.Nil(err)
+	is.Equal(id, v)
+
+	// the largest ULID
+	maxID := ULID(bytes.Repeat([]byte{0xFF}, 16))
+	is.Equal("7ZZZZZZZZZZZZZZZZZZZZZZZZZ", maxID.String())
+	is.Equal(maxID, MustParse("7ZZZZZZZZZZZZZZZZZZZZZZZZZ"))
+	is.Equal(uint64(maxTime), maxID.Timestamp())
+
+	is.Equal("00000000000000000000000000", ULID{}.String())
+
+	// invalid input
+	for _, tc := range []struct {
+		src string
+		err error
+	}{
+		{"", ErrDataSize},
+		{s[1:], ErrDataSize},
+		{s + "0", ErrDataSize},
+		{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", &base32.DecodeError{Offset: 0, Char: '8', Kind: base32.Overflow}},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", &base32.DecodeError{Offset: 25, Char: 'U', Kind: base32.InvalidChar}},
+		{"01ARZ3NDEK-SV4RRFFQ69G5FAV", &base32.DecodeError{Offset: 10, Char: '-', Kind: base32.InvalidChar}},
+	} {
+		v, err := Parse(tc.src)
+		is.Equal(tc.err, err, tc.src)
+		is.Zero(v, tc.src)
+
+		v, err = ParseStrict(tc.src)
+		is.Equal(tc.err, err, tc.src)
+		is.Zero(v, tc.src)
+	}
+
+	is.PanicsWithValue(ErrDataSize, func() {
+		MustParse("")
+	})
+
+	// ordering of the text form matches the ordering of the bytes
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 1000 {
+		var a, b ULID
+		for i := range a {
+			a[i] = byte(r.Uint32())
+			b[i] = byte(r.Uint32())
+		}
+
+		is.Equal(a.Compare(b), strings.Compare(a.String(), b.String()))
+		is.Equal(a, MustParse(a.String()))
+	}
+	is.Zero(id.Compare(id))
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	now := time.UnixMilli(1469922850259).Add(123 * time.Microsecond)
+	entropy := bytes.NewReader([]byte("0123456789"))
+
+	id, err := New(now, entropy)
+	is.Nil(err)
+	is.Equal("01ARZ3NDEK60RK4CSM6MV3EE1S", id.String())
+	is.Equal(now.Truncate(time.Millisecond), id.Time())
+	is.Equal([]byte("0123456789"), id.Entropy())
+
+	// entropy that runs out is an error
+	_, err = New(now, entropy)
+	is.ErrorIs(err, io.EOF)
+
+	_, err = New(now, errReader{})
+	is.ErrorIs(err, errRead)
+
+	// the default entropy source
+	id, err = New(now, nil)
+	is.Nil(err)
+	is.Equal(now.Truncate(time.Millisecond), id.Time())
+
+	id, err = New(time.Now(), nil)
+	is.Nil(err)
+	is.WithinDuration(time.Now(), id.Time(), time.Minute)
+
+	// times that do not fit
+	for _, tm := range []time.Time{
+		time.UnixMilli(-1),
+		time.UnixMilli(maxTime + 1),
+	} {
+		_, err := New(tm, nil)
+		is.ErrorIs(err, ErrTimeRange)
+	}
+
+	id, err = New(time.UnixMilli(maxTime), nil)
+	is.Nil(err)
+	is.Equal(uint64(maxTime), id.Timestamp())
+}
+
+func TestMonotonic(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	now := time.UnixMilli(1469922850259)
+
+	m := NewMonotonic(bytes.NewReader(append(bytes.Repeat([]byte{0xFF}, 9), 0xFD, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)))
+
+	// within the same millisecond the entropy is incremented
+	a, err := m.New(now)
+	is.Nil(err)
+	is.Equal(append(bytes.Repeat([]byte{0xFF}, 9), 0xFD), a.Entropy())
+
+	b, err := m.New(now.Add(500 * time.Microsecond))
+	is.Nil(err)
+	is.Equal(append(bytes.Repeat([]byte{0xFF}, 9), 0xFE), b.Entropy())
+	is.Equal(a.Timestamp(), b.Timestamp())
+
+	// as it is when the clock moves backwards
+	c, err := m.New(now.Add(-time.Second))
+	is.Nil(err)
+	is.Equal(bytes.Repeat([]byte{0xFF}, 10), c.Entropy())
+	is.Equal(a.Timestamp(), c.Timestamp())
+
+	is.Equal(-1, a.Compare(b))
+	is.Equal(-1, b.Compare(c))
+
+	// until it overflows
+	_, err = m.New(now)
+	is.ErrorIs(err, ErrMonotonicOverflow)
+
+	// a later millisecond reads new entropy
+	d, err := m.New(now.Add(time.Millisecond))
+	is.Nil(err)
+	is.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, d.Entropy())
+	is.Equal(-1, c.Compare(d))
+
+	// carries propagate through the entropy
+	e, err := m.New(now.Add(time.Millisecond))
+	is.Nil(err)
+	is.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 11}, e.Entropy())
+
+	_, err = m.New(now.Add(2 * time.Millisecond))
+	is.ErrorIs(err, io.EOF)
+
+	_, err = m.New(time.UnixMilli(-1))
+	is.ErrorIs(err, ErrTimeRange)
+
+	m = NewMonotonic(bytes.NewReader([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0xFF}))
+
+	a, err = m.New(now)
+	is.Nil(err)
+
+	b, err = m.New(now)
+	is.Nil(err)
+	is.Equal([]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0}, b.Entropy())
+	is.Equal(-1, a.Compare(b))
+
+	// concurrent use yields distinct increasing values
+	m = NewMonotonic(nil)
+
+	var (
+		mu  sync.Mutex
+		ids []ULID
+		wg  sync.WaitGroup
+	)
+
+	for range 8 {
+		wg.Go(func() {
+			for range 100 {
+				id, err := m.New(now)
+				is.Nil(err)
+
+				mu.Lock()
+				ids = append(ids, id)
+				mu.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	seen := map[ULID]bool{}
+	for _, id := range ids {
+		is.False(seen[id])
+		seen[id] = true
+		is.Equal(now, id.Time())
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	const s = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	id := MustParse(s)
+
+	// text
+	b, err := id.MarshalText()
+	is.Nil(err)
+	is.Equal(s, string(b))
+
+	b, err = id.AppendText([]byte("id="))
+	is.Nil(err)
+	is.Equal("id="+s, string(b))
+
+	var v ULID
+	is.Nil(v.UnmarshalText([]byte(strings.ToLower(s))))
+	is.Equal(id, v)
+
+	is.Equal(ErrDataSize, v.UnmarshalText([]byte("0")))
+	is.Equal(id, v)
+
+	// JSON
+	type doc struct {
+		ID ULID `json:"id"`
+	}
+
+	b, err = json.Marshal(doc{id})
+	is.Nil(err)
+	is.Equal(`{"id":"`+s+`"}`, string(b))
+
+	var d doc
+	is.Nil(json.Unmarshal(b, &d))
+	is.Equal(id, d.ID)
+
+	is.NotNil(json.Unmarshal([]byte(`{"id":"8ZZZZZZZZZZZZZZZZZZZZZZZZZ"}`), &d))
+
+	// binary
+	b, err = id.MarshalBinary()
+	is.Nil(err)
+	is.Equal(id[:], b)
+
+	b[0] ^= 0xFF
+	is.Equal(byte(0x01), id[0])
+
+	v = ULID{}
+	is.Nil(v.UnmarshalBinary(id[:]))
+	is.Equal(id, v)
+
+	is.Equal(ErrDataSize, v.UnmarshalBinary(id[:15]))
+
+	// SQL
+	dv, err := id.Value()
+	is.Nil(err)
+	is.Equal(s, dv)
+
+	for _, src := range []any{s, []byte(s), id[:]} {
+		v := ULID{}
+		is.Nil(v.Scan(src), src)
+		is.Equal(id, v, src)
+	}
+
+	v = id
+	is.Nil(v.Scan(nil))
+	is.Zero(v)
+
+	is.Equal(ErrScanValue, v.Scan(42))
+	is.Equal(ErrDataSize, v.Scan("0"))
+	is.Equal(ErrDataSize, v.Scan([]byte("0")))
+}
@@ -0,0 +1,130 @@
+package base32
+
+import "errors"
+
+// UUIDEncodedSize is the length of the Crockford encoded form of a
+// UUID.
+const UUIDEncodedSize = 26
+
+var (
+	ErrInvalidUUID        = errors.New("invalid uuid")
+	ErrInvalidUUIDVersion = errors.New("invalid uuid version")
+	ErrInvalidUUIDVariant = errors.New("invalid uuid variant")
+)
+
+// maxUUID is the Max UUID of RFC 9562, which like the Nil UUID has no
+// version or variant.
+var maxUUID = [16]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+
+// EncodeUUID returns the 26 symbol Crockford encoded form of the UUID u.
+//
+// The result is what UnsafeEncode writes for the 16 bytes of u, so it
+// decodes with any Crockford decoder and sorts in the same order as the
+// bytes of u. u is not validated; see ValidUUID.
+func EncodeUUID(u [16]byte) [UUIDEncodedSize]byte {
+	var dst [UUIDEncodedSize]byte
+
+	Crockford.UnsafeEncode(dst[:], u[:])
+
+	return dst
+}
+
+// ParseUUID returns the UUID held by s, which is either the 26 symbol
+// Crockford form returned by EncodeUUID or the canonical 36 character
+// hyphenated hex form, such as "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+// Letters of either case are accepted in both forms.
+//
+// A malformed Crockford form is reported with the *DecodeError Decode
+// would return and a malformed hex form with ErrInvalidUUID, as is any
+// other length. The parsed value must then pass ValidUUID.
+func ParseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+
+	switch len(s) {
+	case UUIDEncodedSize:
+		if err := Crockford.decodeFixed(u[:], s); err != nil {
+			return [16]byte{}, err
+		}
+	case 36:
+		if !parseHexUUID(&u, s) {
+			return [16]byte{}, ErrInvalidUUID
+		}
+	default:
+		return u, ErrInvalidUUID
+	}
+
+	if err := ValidUUID(u); err != nil {
+		return [16]byte{}, err
+	}
+
+	return u, nil
+}
+
+// ValidUUID returns nil if u is an RFC 9562 UUID: either the Nil or Max
+// UUID, or a UUID of the RFC 9562 variant with a version from 1 to 8.
+// Otherwise ErrInvalidUUIDVariant or ErrInvalidUUIDVersion is returned.
+func ValidUUID(u [16]byte) error {
+	if u == [16]byte{} || u == maxUUID {
+		return nil
+	}
+
+	if u[8]&0xC0 != 0x80 {
+		return ErrInvalidUUIDVariant
+	}
+
+	if v := UUIDVersion(u); v < 1 || v > 8 {
+		return ErrInvalidUUIDVersion
+	}
+
+	return nil
+}
+
+// UUIDVersion returns the version field of u, such as 4 for a random
+// UUID or 7 for a time ordered one.
+func UUIDVersion(u [16]byte) int {
+	return int(u[6] >> 4)
+}
+
+// parseHexUUID decodes the hyphenated hex form s into u and reports
+// whether s was well formed.
+//
+// invariants:
+//
+// - len(s) == 36
+func parseHexUUID(u *[16]byte, s string) bool {
+	var j int
+
+	for i := 0; i < len(s); i += 2 {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+			i++
+		}
+
+		hi, ok1 := fromHex(s[i])
+		lo, ok2 := fromHex(s[i+1])
+		if !ok1 || !ok2 {
+			return false
+		}
+
+		u[j] = hi<<4 | lo
+		j++
+	}
+
+	return true
+}
+
+func fromHex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,96 @@
+package base32
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUID(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	// the version 4 and version 7 examples of RFC 9562
+	for _, tc := range []struct {
+		hex     string
+		version int
+	}{
+		{"919108f7-52d1-4320-9bac-f847db4148a8", 4},
+		{"017F22E2-79B0-7CC3-98C4-DC0C0C07398F", 7},
+		{"00000000-0000-0000-0000-000000000000", 0},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", 15},
+	} {
+		u, err := ParseUUID(tc.hex)
+		is.Nil(err, tc.hex)
+		is.Equal(tc.version, UUIDVersion(u), tc.hex)
+		is.Nil(ValidUUID(u), tc.hex)
+
+		s := EncodeUUID(u)
+		is.Equal(EncodeString(string(u[:])), string(s[:]), tc.hex)
+
+		v, err := ParseUUID(string(s[:]))
+		is.Nil(err, tc.hex)
+		is.Equal(u, v, tc.hex)
+
+		v, err = ParseUUID(strings.ToLower(string(s[:])))
+		is.Nil(err, tc.hex)
+		is.Equal(u, v, tc.hex)
+
+		v, err = ParseUUID(strings.ToUpper(tc.hex))
+		is.Nil(err, tc.hex)
+		is.Equal(u, v, tc.hex)
+	}
+
+	u, err := ParseUUID("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	is.Nil(err)
+	is.Equal([16]byte{0x01, 0x7f, 0x22, 0xe2, 0x79, 0xb0, 0x7c, 0xc3, 0x98, 0xc4, 0xdc, 0x0c, 0x0c, 0x07, 0x39, 0x8f}, u)
+
+	s := EncodeUUID(u)
+	is.Equal("05ZJ5RKSP1YC7664VG60R1SSHW", string(s[:]))
+
+	// the encoded form sorts like the bytes
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 1000 {
+		var a, b [16]byte
+		for i := range a {
+			a[i] = byte(r.Uint32())
+			b[i] = byte(r.Uint32())
+		}
+
+		sa, sb := EncodeUUID(a), EncodeUUID(b)
+		is.Equal(strings.Compare(string(a[:]), string(b[:])), strings.Compare(string(sa[:]), string(sb[:])))
+	}
+
+	// invalid input
+	for _, tc := range []struct {
+		src string
+		err error
+	}{
+		{"", ErrInvalidUUID},
+		{"05ZJ5RKSP1YC7664VG60R1SSH", ErrInvalidUUID},
+		{"017f22e279b07cc398c4dc0c0c07398f", ErrInvalidUUID},
+		{"05ZJ5RKSP1YC7664VG60R1SSHU", &DecodeError{Offset: 25, Char: 'U', Kind: InvalidChar}},
+		{"05ZJ5RKSP1YC7664VG60R1SSHX", &DecodeError{Offset: 25, Char: 'X', Kind: NonCanonicalTail}},
+		{"017f22e2_79b0-7cc3-98c4-dc0c0c07398f", ErrInvalidUUID},
+		{"017f22e2-79b0-7cc3-98c4_dc0c0c07398f", ErrInvalidUUID},
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398g", ErrInvalidUUID},
+		{"g17f22e2-79b0-7cc3-98c4-dc0c0c07398f", ErrInvalidUUID},
+		{"017f22e2-79b0-7cc3-08c4-dc0c0c07398f", ErrInvalidUUIDVariant},
+		{"017f22e2-79b0-7cc3-c8c4-dc0c0c07398f", ErrInvalidUUIDVariant},
+		{"017f22e2-79b0-0cc3-98c4-dc0c0c07398f", ErrInvalidUUIDVersion},
+		{"017f22e2-79b0-9cc3-98c4-dc0c0c07398f", ErrInvalidUUIDVersion},
+	} {
+		u, err := ParseUUID(tc.src)
+		is.Equal(tc.err, err, tc.src)
+		is.Zero(u, tc.src)
+	}
+
+	// the first byte of the variant field
+	u[8] = 0xBF
+	is.Nil(ValidUUID(u))
+	u[8] = 0x7F
+	is.Equal(ErrInvalidUUIDVariant, ValidUUID(u))
+}
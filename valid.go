@@ -0,0 +1,214 @@
+package base32
+
+import "unsafe"
+
+// Valid returns nil if src is a valid Crockford encoded value.
+//
+// See Encoding.Valid for details.
+func Valid(src []byte) error {
+	return Crockford.Valid(src)
+}
+
+// ValidString returns nil if src is a valid Crockford encoded value.
+//
+// See Encoding.Valid for details.
+func ValidString(src string) error {
+	return Crockford.ValidString(src)
+}
+
+// ValidLen returns nil if src is a valid Crockford encoded value that
+// decodes to exactly n bytes.
+//
+// See Encoding.ValidLen for details.
+func ValidLen(src []byte, n int) error {
+	return Crockford.ValidLen(src, n)
+}
+
+// ValidStringLen returns nil if src is a valid Crockford encoded value
+// that decodes to exactly n bytes.
+//
+// See Encoding.ValidLen for details.
+func ValidStringLen(src string, n int) error {
+	return Crockford.ValidStringLen(src, n)
+}
+
+// Valid returns nil if Decode would succeed for src, otherwise it
+// returns the same *DecodeError Decode would return. An empty src is
+// valid.
+//
+// The length, padding, character, and tail-bit rules are all checked
+// but nothing is decoded and nothing is allocated.
+func (enc *Encoding) Valid(src []byte) error {
+	if len(src) == 0 {
+		return nil
+	}
+
+	_, err := enc.valid(unsafe.Pointer(&src[0]), len(src))
+	return err
+}
+
+// ValidString returns nil if DecodeString would succeed for src.
+//
+// See Valid for details.
+func (enc *Encoding) ValidString(src string) error {
+	if len(src) == 0 {
+		return nil
+	}
+
+	_, err := enc.valid(unsafe.Pointer(unsafe.StringData(src)), len(src))
+	return err
+}
+
+// ValidLen returns nil if Decode would succeed for src and return
+// exactly n bytes. If src is valid but decodes to a different number of
+// bytes then an InvalidLength *DecodeError is returned.
+//
+// See Valid for details.
+func (enc *Encoding) ValidLen(src []byte, n int) error {
+	if len(src) == 0 {
+		return validSize(0, 0, n)
+	}
+
+	size, err := enc.valid(unsafe.Pointer(&src[0]), len(src))
+	if err != nil {
+		return err
+	}
+
+	return validSize(len(src), size, n)
+}
+
+// ValidStringLen returns nil if DecodeString would succeed for src and
+// return exactly n bytes.
+//
+// See ValidLen for details.
+func (enc *Encoding) ValidStringLen(src string, n int) error {
+	if len(src) == 0 {
+		return validSize(0, 0, n)
+	}
+
+	size, err := enc.valid(unsafe.Pointer(unsafe.StringData(src)), len(src))
+	if err != nil {
+		return err
+	}
+
+	return validSize(len(src), size, n)
+}
+
+// validSize returns an InvalidLength *DecodeError at offset srcLen if
+// the decoded size is not the expected size n.
+func validSize(srcLen, size, n int) error {
+	if size != n {
+		return &DecodeError{Offset: int64(srcLen), Kind: InvalidLength}
+	}
+
+	return nil
+}
+
+// valid validates the n bytes of encoded input at srcPtr and returns
+// the number of bytes they decode to.
+//
+// invariants:
+//
+// - n > 0
+func (enc *Encoding) valid(srcPtr unsafe.Pointer, n int) (int, error) {
+	m, size, err := enc.decodeLen(srcPtr, n)
+	if err != nil {
+		return 0, err
+	}
+
+	if enc.constantTime {
+		if enc.rightAligned {
+			return size, enc.decodeRightAligned(nil, srcPtr, m)
+		}
+
+		return size, enc.decodeConstantTime(nil, srcPtr, m)
+	}
+
+	if enc.sepTab != nil || enc.rightAligned {
+		return size, enc.validSymbols(srcPtr, m, enc.headLen(size))
+	}
+
+	return size, enc.validate(srcPtr, m)
+}
+
+// validate applies the same checks as decode to the n symbols at srcPtr
+// without writing any output.
+//
+// invariants:
+//
+// - decodedLen(n) >= 0
+func (enc *Encoding) validate(srcPtr unsafe.Pointer, n int) error {
+	tab := &enc.decodeTab
+
+	for i := range n / 8 {
+		c0 := tab[*(*byte)(srcPtr)]
+		c1 := tab[*(*byte)(unsafe.Add(srcPtr, 1))]
+		c2 := tab[*(*byte)(unsafe.Add(srcPtr, 2))]
+		c3 := tab[*(*byte)(unsafe.Add(srcPtr, 3))]
+		c4 := tab[*(*byte)(unsafe.Add(srcPtr, 4))]
+		c5 := tab[*(*byte)(unsafe.Add(srcPtr, 5))]
+		c6 := tab[*(*byte)(unsafe.Add(srcPtr, 6))]
+		c7 := tab[*(*byte)(unsafe.Add(srcPtr, 7))]
+
+		if (c0 | c1 | c2 | c3 | c4 | c5 | c6 | c7) == b32Invalid {
+			return enc.decodeError(srcPtr, 8, i*8)
+		}
+
+		srcPtr = unsafe.Add(srcPtr, 8)
+	}
+
+	k := n % 8
+	if k == 0 {
+		return nil
+	}
+
+	var acc, v byte
+	for i := range k {
+		v = tab[*(*byte)(unsafe.Add(srcPtr, i))]
+		acc |= v
+	}
+
+	if acc == b32Invalid || v&tailMask[k]&enc.tailCheck() != 0 {
+		return enc.decodeError(srcPtr, k, n/8*8)
+	}
+
+	return nil
+}
+
+// validSymbols applies the same checks as decodeInput to the first n
+// bytes at srcPtr, one symbol at a time, without writing any output. The
+// leading symbol is checked for overflow once the head of head symbols
+// has been read, as decodeRightAligned does.
+func (enc *Encoding) validSymbols(srcPtr unsafe.Pointer, n, head int) error {
+	sep := enc.sepTab
+
+	var v, first byte
+	var k, firstOff, last int
+	for i := range n {
+		c := *(*byte)(unsafe.Add(srcPtr, i))
+
+		if sep != nil && sep[c] {
+			continue
+		}
+
+		v = enc.decodeTab[c]
+		if v == b32Invalid {
+			return enc.charError(c, i)
+		}
+
+		if k == 0 {
+			first, firstOff = v, i
+		}
+
+		k++
+		last = i
+
+		if k == head {
+			if err := enc.tailError(srcPtr, k, first, v, firstOff, last); err != nil {
+				return err
+			}
+		}
+	}
+
+	return enc.tailError(srcPtr, k, first, v, firstOff, last)
+}
@@ -0,0 +1,98 @@
+package base32
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	t.Parallel()
+
+	is := assert.New(t)
+
+	hyphens := Crockford.WithSeparators("-")
+
+	// Valid must agree with Decode for every input.
+	for _, tc := range []struct {
+		enc *Encoding
+		src string
+	}{
+		{Crockford, ""},
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGE8"},
+		{Crockford, "64s36d1n6rvkge9g64s36d1n6rvkge8"},
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGE"},
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGE4"},
+		{Crockford, "64S36D1N6RVKGE9G64S36D1N6RVKGEU"},
+		{Crockford, "64S36D1N6RVKGE9G"},
+		{Crockford, "64S36D1U6RVKGE9G"},
+		{Crockford, "CR"},
+		{Crockford, "C1"},
+		{Crockford, "CSQG"},
+		{Crockford, "CSQ1"},
+		{Crockford, "CSQPY"},
+		{Crockford, "CSQP1"},
+		{Crockford, "CSQPYRG"},
+		{Crockford, "CSQPYR1"},
+		{Crockford, "CSQPYRU"},
+		{Crockford.Strict(), "64s36d1n"},
+		{hyphens, "64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GE8"},
+		{hyphens, "64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GE4"},
+		{hyphens, "64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GEU"},
+		{hyphens, "64S3-6D1N-6RVK-GE9G-64S3-6D1N-6RVK-GE"},
+		{hyphens, "---"},
+		{Crockford.Strict().WithSeparators("-"), "64S3-6d1N"},
+		{StdEncoding, "MZXW6YTBOI======"},
+		{StdEncoding, "MZXW6YTBOJ======"},
+		{StdEncoding, "MZXW6YTBOI====="},
+		{StdEncoding, "MZXW6YTB========"},
+		{StdEncoding, "MZXW6YT1OI======"},
+		{StdEncoding.WithSeparators("\n"), "MZXW6\nYTBOI\n===\n==="},
+	} {
+		b, decErr := tc.enc.DecodeString(tc.src)
+
+		err := tc.enc.ValidString(tc.src)
+		is.Equal(decErr, err, tc.src)
+
+		err = tc.enc.Valid([]byte(tc.src))
+		is.Equal(decErr, err, tc.src)
+
+		if tc.enc == Crockford {
+			is.Equal(decErr, ValidString(tc.src), tc.src)
+			is.Equal(decErr, Valid([]byte(tc.src)), tc.src)
+		}
+
+		if decErr != nil {
+			is.Equal(decErr, tc.enc.ValidStringLen(tc.src, 0), tc.src)
+			is.Equal(decErr, tc.enc.ValidLen([]byte(tc.src), 0), tc.src)
+			continue
+		}
+
+		is.Nil(tc.enc.ValidStringLen(tc.src, len(b)), tc.src)
+		is.Nil(tc.enc.ValidLen([]byte(tc.src), len(b)), tc.src)
+
+		exp := &DecodeError{Offset: int64(len(tc.src)), Kind: InvalidLength}
+		is.Equal(exp, tc.enc.ValidStringLen(tc.src, len(b)+1), tc.src)
+		is.Equal(exp, tc.enc.ValidLen([]byte(tc.src), len(b)+1), tc.src)
+
+		if tc.enc == Crockford {
+			is.Nil(ValidStringLen(tc.src, len(b)), tc.src)
+			is.Nil(ValidLen([]byte(tc.src), len(b)), tc.src)
+			is.Equal(exp, ValidStringLen(tc.src, len(b)+1), tc.src)
+			is.Equal(exp, ValidLen([]byte(tc.src), len(b)+1), tc.src)
+		}
+	}
+}
+
+// TestValidAllocs is not parallel as AllocsPerRun requires it.
+func TestValidAllocs(t *testing.T) {
+	is := assert.New(t)
+
+	hyphens := Crockford.WithSeparators("-")
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = ValidString("64S36D1N6RVKGE9G64S36D1N6RVKGE8")
+		_ = hyphens.ValidStringLen("64S3-6D1N-6RVK-GE9G", 10)
+	})
+	is.Zero(allocs)
+}